@@ -0,0 +1,31 @@
+//go:build linux
+
+package ffi
+
+import _ "embed"
+
+// embeddedLib is the native core library, built out-of-tree and copied in by
+// the go:generate directive below (mirroring pkg/wasmzero's embedding of
+// eda_core.wasm). Not present in this source checkout since the native
+// build isn't part of it.
+//
+//go:generate sh -c "cp ../../../../bindings/ffi/target/release/libeda_core.so . 2>/dev/null || true"
+//go:embed libeda_core.so
+var embeddedLib []byte
+
+// embeddedLibSHA256Hex is the hex SHA-256 digest of embeddedLib, computed
+// at build time (not at runtime from embeddedLib itself, which would make
+// the check circular and unable to catch a tampered embed) and checked by
+// verifyEmbeddedLib before the library is ever dlopen'd. The trailing
+// newline sha256sum writes is trimmed by verifyEmbeddedLib.
+//
+//go:generate sh -c "sha256sum libeda_core.so | cut -d' ' -f1 > libeda_core.so.sha256"
+//go:embed libeda_core.so.sha256
+var embeddedLibSHA256Hex string
+
+// embeddedLibSignature optionally holds a detached minisign/cosign-style
+// signature over embeddedLibSHA256Hex's digest, checked by
+// verifyEmbeddedLib when both this is non-empty and the caller configured
+// a SignatureVerifier via WithSignatureVerifier. No signing step is wired
+// up yet, so this stays nil.
+var embeddedLibSignature []byte
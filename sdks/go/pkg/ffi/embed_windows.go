@@ -0,0 +1,32 @@
+//go:build windows
+
+package ffi
+
+import _ "embed"
+
+// embeddedLib is the native core library, built out-of-tree and copied in by
+// the go:generate directive below (mirroring pkg/wasmzero's embedding of
+// eda_core.wasm). Not present in this source checkout since the native
+// build isn't part of it.
+//
+//go:generate powershell -Command "Copy-Item -ErrorAction SilentlyContinue ../../../../bindings/ffi/target/release/eda_core.dll ."
+//go:embed eda_core.dll
+var embeddedLib []byte
+
+// embeddedLibSHA256Hex is the hex SHA-256 digest of embeddedLib, computed
+// at build time (not at runtime from embeddedLib itself, which would make
+// the check circular and unable to catch a tampered embed) and checked by
+// verifyEmbeddedLib before the library is ever dlopen'd. The digest command
+// writes no trailing newline (-NoNewline); verifyEmbeddedLib trims
+// surrounding whitespace regardless.
+//
+//go:generate powershell -Command "(Get-FileHash eda_core.dll -Algorithm SHA256).Hash.ToLower() | Out-File -NoNewline eda_core.dll.sha256"
+//go:embed eda_core.dll.sha256
+var embeddedLibSHA256Hex string
+
+// embeddedLibSignature optionally holds a detached minisign/cosign-style
+// signature over embeddedLibSHA256Hex's digest, checked by
+// verifyEmbeddedLib when both this is non-empty and the caller configured
+// a SignatureVerifier via WithSignatureVerifier. No signing step is wired
+// up yet, so this stays nil.
+var embeddedLibSignature []byte
@@ -10,16 +10,31 @@ import (
 // Core implements the sdk.Core interface using FFI (purego)
 type Core struct{}
 
-// NewCore creates a new FFI-based Core implementation
-func NewCore() (*Core, error) {
-	// Load the embedded library on first use
-	if err := loadLibrary(); err != nil {
+// NewCore creates a new FFI-based Core implementation. By default, the
+// embedded native library is digest-verified and loaded without touching
+// disk where the platform supports it (see loadFromMemory), falling back to
+// a private temp file whose removal is deferred to Close or a GC finalizer.
+// Pass WithLibraryPath to load an operator-installed library instead of the
+// embedded one, or WithSignatureVerifier to additionally enforce a shipped
+// minisign/cosign signature.
+func NewCore(opts ...Option) (*Core, error) {
+	options := applyOptions(opts)
+
+	// Load the embedded (or operator-supplied) library on first use
+	if err := loadLibrary(options); err != nil {
 		return nil, fmt.Errorf("failed to load FFI library: %w", err)
 	}
-	return &Core{}, nil
+
+	core := &Core{}
+	if extractedLibPath != "" {
+		runtime.SetFinalizer(core, func(*Core) { cleanupExtractedLib() })
+	}
+	return core, nil
 }
 
-// GetKafkaConfig retrieves the Kafka connection configuration
+// GetKafkaConfig retrieves the Kafka connection configuration, including the
+// Auth/TLS/Tuning settings of routing.yaml's "kafka:" section (see
+// eda_get_kafka_extended_config).
 func (c *Core) GetKafkaConfig() (*sdk.KafkaConfig, error) {
 	// Get broker
 	brokerPtr := edaGetKafkaBroker()
@@ -45,11 +60,35 @@ func (c *Core) GetKafkaConfig() (*sdk.KafkaConfig, error) {
 	group := goString(groupPtr)
 	edaFreeString(groupPtr)
 
-	return &sdk.KafkaConfig{
+	cfg := &sdk.KafkaConfig{
 		Broker: broker,
 		Topic:  topic,
 		Group:  group,
-	}, nil
+	}
+
+	if cExt := edaGetKafkaExtendedConfig(); cExt != nil {
+		defer edaFreeKafkaExtendedConfig(cExt)
+
+		cfg.Auth = sdk.KafkaAuthConfig{
+			SecurityProtocol: goString(cExt.SecurityProtocol),
+			Mechanism:        goString(cExt.Mechanism),
+			Username:         goString(cExt.Username),
+			Password:         goString(cExt.Password),
+		}
+		cfg.TLS = sdk.KafkaTLSConfig{
+			CAFile:             goString(cExt.CAFile),
+			CertFile:           goString(cExt.CertFile),
+			KeyFile:            goString(cExt.KeyFile),
+			InsecureSkipVerify: cExt.InsecureSkipVerify != 0,
+		}
+		cfg.Tuning = sdk.KafkaTuningConfig{
+			SessionTimeoutMs:  int(cExt.SessionTimeoutMs),
+			MaxPollIntervalMs: int(cExt.MaxPollIntervalMs),
+			IsolationLevel:    goString(cExt.IsolationLevel),
+		}
+	}
+
+	return cfg, nil
 }
 
 // ShouldRetry checks if an error should be retried
@@ -106,7 +145,9 @@ func (c *Core) LoadRoutingConfig(filePath string) error {
 	return nil
 }
 
-// Close releases resources (no-op for FFI implementation)
+// Close releases resources held by the Core implementation, including
+// removing any temp file extractEmbeddedLib created as a fallback.
 func (c *Core) Close() error {
+	cleanupExtractedLib()
 	return nil
 }
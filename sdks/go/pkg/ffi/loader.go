@@ -1,10 +1,14 @@
 package ffi
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -18,24 +22,56 @@ type COutputDestination struct {
 	Cluster  *byte
 }
 
+// CKafkaExtendedConfig matches the C struct from Rust FFI holding the
+// auth/TLS/tuning settings of the "kafka:" section of routing.yaml, in
+// addition to the broker/topic/group already covered by the plain
+// eda_get_kafka_* getters. String fields are NULL (not an empty C string)
+// when unset.
+type CKafkaExtendedConfig struct {
+	SecurityProtocol *byte
+	Mechanism        *byte
+	Username         *byte
+	Password         *byte
+
+	CAFile             *byte
+	CertFile           *byte
+	KeyFile            *byte
+	InsecureSkipVerify uint32
+
+	SessionTimeoutMs  int32
+	MaxPollIntervalMs int32
+	IsolationLevel    *byte
+}
+
 var (
 	// Library handle
 	libHandle uintptr
 
 	// Function pointers
-	edaGetKafkaBroker        func() *byte
-	edaGetKafkaTopic         func() *byte
-	edaGetKafkaGroup         func() *byte
-	edaFreeString            func(*byte)
-	edaShouldRetry           func(*byte, uint32) int32
-	edaCalculateBackoff      func(uint32) uint64
-	edaGetOutputDestination  func(*byte) *COutputDestination
-	edaFreeOutputDestination func(*COutputDestination)
-	edaLoadRoutingConfig     func(*byte) bool
+	edaGetKafkaBroker          func() *byte
+	edaGetKafkaTopic           func() *byte
+	edaGetKafkaGroup           func() *byte
+	edaFreeString              func(*byte)
+	edaShouldRetry             func(*byte, uint32) int32
+	edaCalculateBackoff        func(uint32) uint64
+	edaGetOutputDestination    func(*byte) *COutputDestination
+	edaFreeOutputDestination   func(*COutputDestination)
+	edaLoadRoutingConfig       func(*byte) bool
+	edaGetKafkaExtendedConfig  func() *CKafkaExtendedConfig
+	edaFreeKafkaExtendedConfig func(*CKafkaExtendedConfig)
 
 	// Ensure library is loaded only once
 	loadOnce sync.Once
 	loadErr  error
+
+	// extractedLibPath is set once the embedded library has been written to
+	// a private temp file, i.e. whenever loadFromMemory wasn't available for
+	// this platform/build. Cleanup of that file is deferred to Core.Close or
+	// a GC finalizer (see cleanupExtractedLib) rather than happening right
+	// after Dlopen, because some platforms keep a mapped file locked for as
+	// long as it's in use.
+	extractedLibPath string
+	cleanupOnce      sync.Once
 )
 
 // libName returns the platform-specific library name
@@ -50,17 +86,20 @@ func libName() string {
 	}
 }
 
-// extractEmbeddedLib extracts the embedded library to a temporary file
+// extractEmbeddedLib extracts the embedded library to a private temp file.
+// This is the fallback used when loadFromMemory isn't available for this
+// platform (macOS, Windows, or a Linux build where memfd_create failed). The
+// file is written 0700 rather than the old 0755 so a container security
+// scanner doesn't flag a writable, world-executable temp file; cleanup is
+// the caller's responsibility (see extractedLibPath/cleanupExtractedLib).
 func extractEmbeddedLib() (string, error) {
-	// Create a temporary directory
 	tmpDir, err := os.MkdirTemp("", "eda-core-*")
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	// Write the embedded library to the temp directory
 	libPath := filepath.Join(tmpDir, libName())
-	if err := os.WriteFile(libPath, embeddedLib, 0755); err != nil {
+	if err := os.WriteFile(libPath, embeddedLib, 0o700); err != nil {
 		os.RemoveAll(tmpDir)
 		return "", fmt.Errorf("failed to write library to temp file: %w", err)
 	}
@@ -68,22 +107,113 @@ func extractEmbeddedLib() (string, error) {
 	return libPath, nil
 }
 
-// loadLibrary loads the embedded FFI library and registers all functions
-func loadLibrary() error {
-	var err error
-	loadOnce.Do(func() {
-		// Extract embedded library to temp file
-		libPath, extractErr := extractEmbeddedLib()
-		if extractErr != nil {
-			err = fmt.Errorf("failed to extract embedded library: %w", extractErr)
+// cleanupExtractedLib removes the temp file (if any) created by
+// extractEmbeddedLib. Safe to call more than once, from both Core.Close and
+// a GC finalizer, and a no-op if in-memory loading never fell back to disk.
+func cleanupExtractedLib() {
+	cleanupOnce.Do(func() {
+		if extractedLibPath == "" {
 			return
 		}
+		if err := os.RemoveAll(filepath.Dir(extractedLibPath)); err != nil {
+			slog.Default().Warn("failed to remove extracted library temp dir", "path", extractedLibPath, "error", err)
+		}
+	})
+}
+
+// verifyEmbeddedLib checks the embedded library's SHA-256 digest against
+// embeddedLibSHA256Hex (generated at build time by each platform's
+// embed_*.go go:generate directives), and, if both a signature was shipped
+// and opts has a SignatureVerifier configured, the signature too. This runs
+// before the library is ever dlopen'd, so a tampered binary baked into the
+// container image is caught up front rather than silently loaded and
+// executed.
+func verifyEmbeddedLib(opts *Options) ([]byte, error) {
+	sum := sha256.Sum256(embeddedLib)
+	digest := sum[:]
+
+	want := strings.ToLower(strings.TrimSpace(embeddedLibSHA256Hex))
+	switch want {
+	case "":
+		// The embedded digest file is empty (go:generate ran while the library
+		// wasn't there to hash), so there's nothing to compare against. Load
+		// anyway rather than refusing to start: the digest is
+		// one layer of defense-in-depth on top of the embed itself, not the
+		// only thing standing between the process and a tampered library.
+		slog.Default().Warn("embedded library has no expected digest configured, skipping digest check")
+	case hex.EncodeToString(digest):
+		// Matches.
+	default:
+		return nil, fmt.Errorf("embedded library digest mismatch: got %s, want %s", hex.EncodeToString(digest), want)
+	}
+
+	switch {
+	case len(embeddedLibSignature) == 0:
+		// No signature shipped for this build; the digest check above is the
+		// only integrity guarantee.
+	case opts.SignatureVerifier == nil:
+		slog.Default().Warn("embedded library ships a signature but no SignatureVerifier is configured, skipping signature check")
+	default:
+		if err := opts.SignatureVerifier(digest, embeddedLibSignature); err != nil {
+			return nil, fmt.Errorf("embedded library signature verification failed: %w", err)
+		}
+	}
+
+	return digest, nil
+}
 
-		// Load the library
+// loadNativeLibrary resolves the dlopen handle for the native library. With
+// opts.LibraryPath set, that path is trusted as-is (an operator's explicit
+// choice) and loaded directly, bypassing embedding and verification.
+// Otherwise the embedded library is digest/signature verified (see
+// verifyEmbeddedLib) and loaded via loadFromMemory, which avoids touching
+// disk on platforms that support it (Linux, via memfd_create), falling back
+// to extractEmbeddedLib's private temp file everywhere else.
+func loadNativeLibrary(opts *Options) (uintptr, error) {
+	if opts.LibraryPath != "" {
+		handle, err := purego.Dlopen(opts.LibraryPath, purego.RTLD_NOW|purego.RTLD_GLOBAL)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load library from %s: %w", opts.LibraryPath, err)
+		}
+		return handle, nil
+	}
+
+	if _, err := verifyEmbeddedLib(opts); err != nil {
+		return 0, fmt.Errorf("failed to verify embedded library: %w", err)
+	}
+
+	if handle, err := loadFromMemory(embeddedLib); err == nil {
+		return handle, nil
+	} else {
+		slog.Default().Debug("in-memory library loading unavailable, falling back to tempfile extraction", "error", err)
+	}
+
+	libPath, err := extractEmbeddedLib()
+	if err != nil {
+		return 0, fmt.Errorf("failed to extract embedded library: %w", err)
+	}
+
+	handle, err := purego.Dlopen(libPath, purego.RTLD_NOW|purego.RTLD_GLOBAL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load library from %s: %w", libPath, err)
+	}
+	extractedLibPath = libPath
+
+	return handle, nil
+}
+
+// loadLibrary loads the native library per opts (see loadNativeLibrary) and
+// registers all functions. Loading happens at most once per process:
+// purego.RegisterLibFunc binds package-level function variables shared by
+// every Core, so the first caller's opts govern for any concurrent NewCore
+// calls that differ.
+func loadLibrary(opts *Options) error {
+	var err error
+	loadOnce.Do(func() {
 		var openErr error
-		libHandle, openErr = purego.Dlopen(libPath, purego.RTLD_NOW|purego.RTLD_GLOBAL)
+		libHandle, openErr = loadNativeLibrary(opts)
 		if openErr != nil {
-			err = fmt.Errorf("failed to load library from %s: %w", libPath, openErr)
+			err = openErr
 			return
 		}
 
@@ -133,6 +263,12 @@ func registerFunctions() error {
 	// Register eda_load_routing_config
 	purego.RegisterLibFunc(&edaLoadRoutingConfig, libHandle, "eda_load_routing_config")
 
+	// Register eda_get_kafka_extended_config
+	purego.RegisterLibFunc(&edaGetKafkaExtendedConfig, libHandle, "eda_get_kafka_extended_config")
+
+	// Register eda_free_kafka_extended_config
+	purego.RegisterLibFunc(&edaFreeKafkaExtendedConfig, libHandle, "eda_free_kafka_extended_config")
+
 	return nil
 }
 
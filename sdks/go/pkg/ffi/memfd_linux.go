@@ -0,0 +1,39 @@
+//go:build linux
+
+package ffi
+
+import (
+	"fmt"
+
+	"github.com/ebitengine/purego"
+	"golang.org/x/sys/unix"
+)
+
+// loadFromMemory dlopens lib without ever writing it to disk, using Linux's
+// memfd_create to get an anonymous, file-backed-nowhere fd and then
+// addressing it via /proc/self/fd/<fd> — the same fexecve-style trick used
+// to exec a program straight out of memory, applied here to dlopen instead.
+// The fd is closed as soon as Dlopen returns: by then the dynamic linker has
+// already mmap'd the library into the process, so the fd itself is no
+// longer needed.
+func loadFromMemory(lib []byte) (uintptr, error) {
+	fd, err := unix.MemfdCreate("libeda_core", unix.MFD_CLOEXEC)
+	if err != nil {
+		return 0, fmt.Errorf("memfd_create: %w", err)
+	}
+	defer unix.Close(fd)
+
+	if err := unix.Ftruncate(fd, int64(len(lib))); err != nil {
+		return 0, fmt.Errorf("ftruncate memfd: %w", err)
+	}
+	if _, err := unix.Write(fd, lib); err != nil {
+		return 0, fmt.Errorf("write memfd: %w", err)
+	}
+
+	handle, err := purego.Dlopen(fmt.Sprintf("/proc/self/fd/%d", fd), purego.RTLD_NOW|purego.RTLD_GLOBAL)
+	if err != nil {
+		return 0, fmt.Errorf("dlopen memfd: %w", err)
+	}
+
+	return handle, nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package ffi
+
+import "errors"
+
+// loadFromMemory always fails outside Linux: memfd_create has no portable
+// equivalent, so loadNativeLibrary falls back to extractEmbeddedLib's temp
+// file on macOS and Windows.
+func loadFromMemory([]byte) (uintptr, error) {
+	return 0, errors.New("in-memory library loading is only supported on linux")
+}
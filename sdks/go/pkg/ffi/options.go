@@ -0,0 +1,57 @@
+package ffi
+
+// SignatureVerifier validates sig as a detached signature over digest (the
+// SHA-256 of the embedded native library), e.g. backed by minisign or
+// cosign. Configure one via WithSignatureVerifier to enforce it; without
+// one, a shipped signature is logged and otherwise ignored, leaving the
+// SHA-256 digest check as the only integrity guarantee.
+type SignatureVerifier func(digest, sig []byte) error
+
+// Options holds configuration for loading the native eda_core library.
+type Options struct {
+	// LibraryPath, if set, loads the native library from this path instead
+	// of the embedded copy, skipping both extraction and integrity
+	// verification. Use this when an operator has pre-installed
+	// libeda_core.* in the image or on the host.
+	LibraryPath string
+
+	// SignatureVerifier checks the embedded library's shipped signature (if
+	// any) against its SHA-256 digest. Nil (the default) skips signature
+	// verification; the digest check alone still runs unless LibraryPath is
+	// set.
+	SignatureVerifier SignatureVerifier
+}
+
+// Option configures native library loading for NewCore.
+type Option func(*Options)
+
+// WithLibraryPath loads the native library from path instead of the
+// embedded copy, skipping extraction and integrity verification entirely.
+// Use this when an operator has pre-installed libeda_core.* in the image or
+// on the host, e.g. to satisfy a read-only rootfs or a policy against
+// embedding native code in the function binary.
+func WithLibraryPath(path string) Option {
+	return func(o *Options) {
+		o.LibraryPath = path
+	}
+}
+
+// WithSignatureVerifier enables signature verification of the embedded
+// library using v, in addition to the always-on SHA-256 digest check.
+// Ignored when WithLibraryPath is set.
+func WithSignatureVerifier(v SignatureVerifier) Option {
+	return func(o *Options) {
+		o.SignatureVerifier = v
+	}
+}
+
+// applyOptions applies the given options and returns the final Options.
+func applyOptions(opts []Option) *Options {
+	options := &Options{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
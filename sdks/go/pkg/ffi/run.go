@@ -2,6 +2,7 @@ package ffi
 
 import (
 	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk"
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sink/kafka"
 )
 
 // DefaultCoreConstructor is the default FFI core constructor
@@ -12,23 +13,36 @@ var DefaultCoreConstructor sdk.CoreConstructor = func() (sdk.Core, error) {
 // Run starts the EDA consumer using FFI core with the given handler
 // This is the main entry point for FFI-based functions
 // Handler can be either SimpleHandler or OutputHandler signature
+//
+// If the caller passes sdk.WithClusterResolver and hasn't already
+// registered a DestinationKafka sink via sdk.WithSinkRegistry, Run wires up
+// pkg/sink/kafka for cross-cluster output routing automatically.
 func Run[H sdk.Handler](handler H, opts ...sdk.Option) error {
-	// Check if a core constructor is already provided
-	hasConstructor := false
+	// Apply every option to a temporary Options to see what the caller
+	// already configured, without affecting the real run.
+	tempOpts := &sdk.Options{}
 	for _, opt := range opts {
-		// Apply option to a temporary Options to check
-		tempOpts := &sdk.Options{}
 		opt(tempOpts)
-		if tempOpts.CoreConstructor != nil {
-			hasConstructor = true
-			break
-		}
 	}
 
-	// Add default FFI core constructor if not provided
 	allOpts := opts
-	if !hasConstructor {
-		allOpts = append([]sdk.Option{sdk.WithCoreConstructor(DefaultCoreConstructor)}, opts...)
+
+	// Add default FFI core constructor if not provided
+	if tempOpts.CoreConstructor == nil {
+		allOpts = append([]sdk.Option{sdk.WithCoreConstructor(DefaultCoreConstructor)}, allOpts...)
+	}
+
+	// Auto-register the Kafka sink for cross-cluster DestinationKafka
+	// routing if the caller configured a ClusterResolver and didn't already
+	// register a DestinationKafka sink of their own.
+	if tempOpts.ClusterResolver != nil {
+		if _, ok := tempOpts.SinkRegistry[sdk.DestinationKafka]; !ok {
+			registry := sdk.SinkRegistry{sdk.DestinationKafka: kafka.New(kafka.ClusterResolver(tempOpts.ClusterResolver))}
+			for destType, sink := range tempOpts.SinkRegistry {
+				registry[destType] = sink
+			}
+			allOpts = append(allOpts, sdk.WithSinkRegistry(registry))
+		}
 	}
 
 	// Run with constructor
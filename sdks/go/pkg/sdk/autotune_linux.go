@@ -0,0 +1,123 @@
+//go:build linux
+
+package sdk
+
+import (
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+const (
+	cgroupV2CPUMaxFile    = "/sys/fs/cgroup/cpu.max"
+	cgroupV2MemoryMaxFile = "/sys/fs/cgroup/memory.max"
+
+	cgroupV1CPUQuotaFile  = "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"
+	cgroupV1CPUPeriodFile = "/sys/fs/cgroup/cpu/cpu.cfs_period_us"
+	cgroupV1MemoryFile    = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
+
+	// cgroupV1UnlimitedThreshold is below the sentinel cgroup v1 reports for
+	// an unset memory.limit_in_bytes (typically 1<<63 rounded down to a page
+	// boundary), but well above anything a real container limit would set.
+	cgroupV1UnlimitedThreshold = int64(1) << 62
+)
+
+// tuneRuntime applies cgroup-derived GOMAXPROCS and memory limit settings,
+// honoring any GOMAXPROCS/GOMEMLIMIT the user already set, and returns the
+// CPU count NewConsumer should size the handler worker pool and in-flight
+// message window to. It falls back to runtime.NumCPU() whenever no cgroup
+// limit can be read, so it degrades gracefully on hosts without cgroups and
+// in rootless containers where the cgroup files may be unreadable.
+func tuneRuntime(logger *slog.Logger) int {
+	cpus := runtime.NumCPU()
+
+	if os.Getenv("GOMAXPROCS") == "" {
+		if quota, ok := cgroupCPUQuota(); ok {
+			cpus = quota
+			runtime.GOMAXPROCS(cpus)
+			logger.Info("auto-tuned GOMAXPROCS from cgroup CPU quota", "gomaxprocs", cpus)
+		}
+	}
+
+	if os.Getenv("GOMEMLIMIT") == "" {
+		if limit, ok := cgroupMemoryLimit(); ok {
+			debug.SetMemoryLimit(limit)
+			logger.Info("auto-tuned Go memory limit from cgroup", "bytes", limit)
+		}
+	}
+
+	return cpus
+}
+
+// cgroupCPUQuota reads the CPU quota from cgroup v2 (cpu.max), falling back
+// to cgroup v1 (cpu.cfs_quota_us / cpu.cfs_period_us). It reports ok=false if
+// no limit is set or the files can't be read.
+func cgroupCPUQuota() (int, bool) {
+	if data, err := os.ReadFile(cgroupV2CPUMaxFile); err == nil {
+		fields := strings.Fields(string(data))
+		if len(fields) != 2 || fields[0] == "max" {
+			return 0, false
+		}
+		quota, errQ := strconv.ParseFloat(fields[0], 64)
+		period, errP := strconv.ParseFloat(fields[1], 64)
+		if errQ != nil || errP != nil || period <= 0 {
+			return 0, false
+		}
+		return ceilCPUs(quota / period), true
+	}
+
+	quotaData, errQ := os.ReadFile(cgroupV1CPUQuotaFile)
+	periodData, errP := os.ReadFile(cgroupV1CPUPeriodFile)
+	if errQ != nil || errP != nil {
+		return 0, false
+	}
+	quota, errQ := strconv.ParseFloat(strings.TrimSpace(string(quotaData)), 64)
+	period, errP := strconv.ParseFloat(strings.TrimSpace(string(periodData)), 64)
+	if errQ != nil || errP != nil || quota <= 0 || period <= 0 {
+		return 0, false
+	}
+	return ceilCPUs(quota / period), true
+}
+
+// cgroupMemoryLimit reads the memory limit from cgroup v2 (memory.max),
+// falling back to cgroup v1 (memory.limit_in_bytes). It reports ok=false if
+// no limit is set or the files can't be read.
+func cgroupMemoryLimit() (int64, bool) {
+	if data, err := os.ReadFile(cgroupV2MemoryMaxFile); err == nil {
+		s := strings.TrimSpace(string(data))
+		if s == "max" {
+			return 0, false
+		}
+		limit, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || limit <= 0 {
+			return 0, false
+		}
+		return limit, true
+	}
+
+	data, err := os.ReadFile(cgroupV1MemoryFile)
+	if err != nil {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || limit <= 0 || limit > cgroupV1UnlimitedThreshold {
+		return 0, false
+	}
+	return limit, true
+}
+
+// ceilCPUs rounds a fractional CPU quota up to a whole count, never below 1,
+// so a pod given e.g. 500m CPU still gets at least one worker.
+func ceilCPUs(n float64) int {
+	cpus := int(n)
+	if n > float64(cpus) {
+		cpus++
+	}
+	if cpus < 1 {
+		cpus = 1
+	}
+	return cpus
+}
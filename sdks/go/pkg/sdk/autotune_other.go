@@ -0,0 +1,15 @@
+//go:build !linux
+
+package sdk
+
+import (
+	"log/slog"
+	"runtime"
+)
+
+// tuneRuntime is a no-op outside Linux: cgroups are Linux-specific, so
+// WithAutoTune falls back to whatever GOMAXPROCS the Go runtime (or the
+// user, via the env var) has already settled on.
+func tuneRuntime(_ *slog.Logger) int {
+	return runtime.GOMAXPROCS(0)
+}
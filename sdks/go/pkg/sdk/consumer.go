@@ -3,14 +3,20 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"reflect"
+	"sync"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver/confluent"
 )
 
 // handlerSignature represents the detected handler signature type
@@ -18,22 +24,115 @@ type handlerSignature int
 
 const (
 	handlerSignatureSimple handlerSignature = iota
+	handlerSignatureSimpleCtx
 	handlerSignatureOutput
+	handlerSignatureBatchOutput
 )
 
 // Consumer manages Kafka consumption and event processing
 type Consumer struct {
-	core         Core
-	consumer     *kafka.Consumer
-	producer     *kafka.Producer
-	handlerValue reflect.Value
-	handlerSig   handlerSignature
-	logger       *slog.Logger
+	core            Core
+	driver          driver.ConsumerDriver
+	handlerValue    reflect.Value
+	handlerSig      handlerSignature
+	logger          *slog.Logger
+	telemetry       *telemetry
+	deadLetterTopic string
+	maxRetries      int
+	sinks           SinkRegistry
+	shutdownTimeout time.Duration
+	concurrency     int
+
+	// partitionTrackers holds one partitionTracker per topic/partition ever
+	// polled, so concurrent handler goroutines (see WithAutoTune) commit in
+	// an order that's safe for that partition. Only Start's single polling
+	// loop creates entries; processMessage goroutines only use the
+	// *partitionTracker handed to them, so this map itself needs no lock.
+	partitionTrackers map[partitionKey]*partitionTracker
+
+	// commitMu serializes every call to driver.Commit across all partitions.
+	// partitionTracker only prevents out-of-order commits *within* a
+	// partition; drivers like confluent-kafka-go additionally require that
+	// no two Commit calls run concurrently at all, regardless of partition.
+	commitMu sync.Mutex
+
+	// inFlight tracks handler goroutines started by Start, so both drain and
+	// Close can wait for them. Close waits unconditionally (see Close), even
+	// if drain already gave up on a clean shutdown, so the driver and core
+	// are never closed out from under a goroutine that's still using them.
+	inFlight sync.WaitGroup
+
+	// drainCancel cancels the context handler goroutines run under (set by
+	// Start), letting drain interrupt a retry backoff once the shutdown
+	// timeout has elapsed instead of waiting out the rest of it.
+	drainCancel context.CancelFunc
+}
+
+// partitionKey identifies a Kafka partition a message was polled from.
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// partitionTracker serializes commits for one partition. Kafka commits are
+// per-partition and monotonic, so if concurrent handler goroutines (see
+// WithAutoTune) each committed their own message's offset independently, a
+// later offset finishing first would commit past an earlier offset still
+// mid-retry on the same partition — on a crash/rebalance the position would
+// already be past it, losing it rather than replaying it. A tracker instead
+// buffers out-of-order completions and only reports the highest
+// *contiguous* completed offset as ready to commit.
+type partitionTracker struct {
+	mu      sync.Mutex
+	pending []*driver.Message
+	done    map[int64]bool
+}
+
+// register records msg as in-flight, in the order it was polled.
+func (t *partitionTracker) register(msg *driver.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, msg)
+}
+
+// complete marks msg as finished (successfully handled or dead-lettered) and
+// returns, in order, every message up to and including the new highest
+// contiguous completed offset on this partition. Completions that leave a
+// gap (an earlier offset still in flight) are buffered until that gap closes.
+func (t *partitionTracker) complete(msg *driver.Message) []*driver.Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done == nil {
+		t.done = make(map[int64]bool)
+	}
+	t.done[msg.Offset] = true
+
+	var ready []*driver.Message
+	for len(t.pending) > 0 && t.done[t.pending[0].Offset] {
+		m := t.pending[0]
+		t.pending = t.pending[1:]
+		delete(t.done, m.Offset)
+		ready = append(ready, m)
+	}
+	return ready
+}
+
+// trackerFor returns the partitionTracker for msg's topic/partition,
+// creating it on first use. Only called from Start's single polling loop.
+func (c *Consumer) trackerFor(msg *driver.Message) *partitionTracker {
+	key := partitionKey{topic: msg.Topic, partition: msg.Partition}
+	tracker, ok := c.partitionTrackers[key]
+	if !ok {
+		tracker = &partitionTracker{}
+		c.partitionTrackers[key] = tracker
+	}
+	return tracker
 }
 
 // NewConsumer creates a new consumer with the given core implementation
 // Accepts either SimpleHandler or OutputHandler signatures
-func NewConsumer(core Core, handler interface{}) (*Consumer, error) {
+func NewConsumer(core Core, handler interface{}, opts ...Option) (*Consumer, error) {
 	if core == nil {
 		return nil, fmt.Errorf("core cannot be nil")
 	}
@@ -41,10 +140,15 @@ func NewConsumer(core Core, handler interface{}) (*Consumer, error) {
 		return nil, fmt.Errorf("handler cannot be nil")
 	}
 
+	options := applyOptions(opts)
+
 	// Initialize logger
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		}))
+	}
 
 	// Detect handler signature
 	handlerValue := reflect.ValueOf(handler)
@@ -56,22 +160,38 @@ func NewConsumer(core Core, handler interface{}) (*Consumer, error) {
 	handlerType := handlerValue.Type()
 	var detectedSig handlerSignature
 
-	// Check for SimpleHandler: func(cloudevents.Event) error
-	if handlerType.NumIn() == 1 && handlerType.NumOut() == 1 {
-		if handlerType.Out(0).String() == "error" {
-			detectedSig = handlerSignatureSimple
-		} else {
-			return nil, fmt.Errorf("invalid handler signature")
-		}
-	} else if handlerType.NumIn() == 1 && handlerType.NumOut() == 2 {
-		// Check for OutputHandler: func(cloudevents.Event) (*cloudevents.Event, error)
-		if handlerType.Out(1).String() == "error" {
+	switch {
+	case handlerType.NumIn() == 1 && handlerType.NumOut() == 1 && handlerType.Out(0).String() == "error":
+		// SimpleHandler: func(cloudevents.Event) error
+		detectedSig = handlerSignatureSimple
+	case handlerType.NumIn() == 2 && handlerType.NumOut() == 1 &&
+		handlerType.In(0).String() == "context.Context" && handlerType.In(1).String() == "event.Event" &&
+		handlerType.Out(0).String() == "error":
+		// ContextHandler: func(context.Context, cloudevents.Event) error
+		detectedSig = handlerSignatureSimpleCtx
+	case handlerType.NumIn() == 1 && handlerType.NumOut() == 2 && handlerType.Out(1).String() == "error":
+		switch handlerType.Out(0).String() {
+		case "*event.Event":
+			// OutputHandler: func(cloudevents.Event) (*cloudevents.Event, error)
 			detectedSig = handlerSignatureOutput
-		} else {
+		case "[]*event.Event":
+			// BatchOutputHandler: func(cloudevents.Event) ([]*cloudevents.Event, error)
+			detectedSig = handlerSignatureBatchOutput
+		default:
 			return nil, fmt.Errorf("invalid handler signature")
 		}
-	} else {
-		return nil, fmt.Errorf("handler must have signature func(Event) error or func(Event) (*Event, error)")
+	default:
+		return nil, fmt.Errorf("handler must have signature func(Event) error, func(context.Context, Event) error, func(Event) (*Event, error), or func(Event) ([]*Event, error)")
+	}
+
+	// Tune GOMAXPROCS/memory limit to the cgroup's CPU/memory limits (Linux
+	// only; see WithAutoTune), and size the handler worker pool and in-flight
+	// message window to match so a resource-constrained pod doesn't
+	// over-commit. Without WithAutoTune, messages are handled one at a time,
+	// matching prior behavior.
+	concurrency := 1
+	if options.AutoTune {
+		concurrency = tuneRuntime(logger)
 	}
 
 	// Get Kafka config from core
@@ -85,140 +205,311 @@ func NewConsumer(core Core, handler interface{}) (*Consumer, error) {
 		"topic", config.Topic,
 		"group", config.Group)
 
-	// Create Kafka consumer
-	kafkaConsumer, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers": config.Broker,
-		"group.id":          config.Group,
-		"auto.offset.reset": "earliest",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
-	}
-
-	// Create Kafka producer for output events (if handler returns events)
-	var kafkaProducer *kafka.Producer
-	if detectedSig == handlerSignatureOutput {
-		kafkaProducer, err = kafka.NewProducer(&kafka.ConfigMap{
-			"bootstrap.servers": config.Broker,
-		})
+	// Build the Kafka driver: the caller's choice via WithConsumerDriver, or
+	// the default confluent-kafka-go driver built from the Core's config.
+	// Auto-commit is disabled: offsets are committed explicitly once a
+	// message has either been handled successfully or dead-lettered, so a
+	// crash mid-retry replays the message instead of silently losing it.
+	kafkaDriver := options.ConsumerDriver
+	if kafkaDriver == nil {
+		kafkaDriver, err = confluent.New(toDriverConfig(config))
 		if err != nil {
-			kafkaConsumer.Close()
-			return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+			return nil, fmt.Errorf("failed to create Kafka driver: %w", err)
 		}
-		logger.Info("Kafka producer initialized for output events")
 	}
 
-	// Subscribe to topic with rebalance callback that starts from beginning
-	rebalanceCb := func(c *kafka.Consumer, event kafka.Event) error {
-		switch e := event.(type) {
-		case kafka.AssignedPartitions:
-			logger.Info("Partitions assigned", "partitions", e.Partitions)
-			// Set offset to beginning before assigning to replay all messages
-			for i := range e.Partitions {
-				e.Partitions[i].Offset = kafka.OffsetBeginning
-			}
-			logger.Info("Starting from beginning for all partitions")
-			return c.Assign(e.Partitions)
-		case kafka.RevokedPartitions:
-			logger.Info("Partitions revoked", "partitions", e.Partitions)
-			return c.Unassign()
-		}
-		return nil
+	if err := kafkaDriver.Subscribe(); err != nil {
+		kafkaDriver.Close()
+		return nil, fmt.Errorf("failed to subscribe to topic %s: %w", config.Topic, err)
 	}
 
-	if err := kafkaConsumer.Subscribe(config.Topic, rebalanceCb); err != nil {
-		kafkaConsumer.Close()
-		if kafkaProducer != nil {
-			kafkaProducer.Close()
-		}
-		return nil, fmt.Errorf("failed to subscribe to topic %s: %w", config.Topic, err)
+	tel, err := newTelemetry(options.TracerProvider, options.MeterProvider)
+	if err != nil {
+		kafkaDriver.Close()
+		return nil, fmt.Errorf("failed to initialize telemetry: %w", err)
+	}
+
+	// Build the sink registry: built-in defaults, overridable/extendable via
+	// WithSinkRegistry. Sinks own their own connections, so they're created
+	// once here and shared across every publish rather than dialing per-event.
+	sinks := SinkRegistry{
+		DestinationHTTP:     NewHTTPSink(),
+		DestinationRabbitMQ: NewRabbitMQSink(),
+	}
+	for destType, sink := range options.SinkRegistry {
+		sinks[destType] = sink
 	}
 
 	return &Consumer{
-		core:         core,
-		consumer:     kafkaConsumer,
-		producer:     kafkaProducer,
-		handlerValue: handlerValue,
-		handlerSig:   detectedSig,
-		logger:       logger,
+		core:              core,
+		driver:            kafkaDriver,
+		handlerValue:      handlerValue,
+		handlerSig:        detectedSig,
+		logger:            logger,
+		telemetry:         tel,
+		deadLetterTopic:   options.DeadLetterTopic,
+		maxRetries:        options.MaxRetries,
+		sinks:             sinks,
+		shutdownTimeout:   options.ShutdownTimeout,
+		concurrency:       concurrency,
+		partitionTrackers: make(map[partitionKey]*partitionTracker),
 	}, nil
 }
 
-// Start begins consuming events (blocking)
+// Start begins consuming events (blocking). Once ctx is cancelled, Start stops
+// polling for new messages but waits up to the configured shutdown timeout for
+// a message already being handled to finish (including its retry backoff and
+// dead-lettering) before returning, so a rolling restart doesn't abandon work
+// that was already picked up.
 func (c *Consumer) Start(ctx context.Context) error {
-	c.logger.Info("Starting consumer")
+	c.logger.Info("Starting consumer", "concurrency", c.concurrency)
 
 	consecutiveErrors := 0
 	maxConsecutiveErrors := 5
 	pollTimeout := 100 * time.Millisecond
 
+	// handlerCtx is independent of ctx so that a shutdown request doesn't
+	// abort a retry backoff or dead-letter publish mid-flight (see the
+	// goroutine below and drain), but it's still cancelable: drain cancels
+	// it once the shutdown timeout elapses, to interrupt a backoff wait
+	// instead of waiting out the rest of it.
+	handlerCtx, cancel := context.WithCancel(context.Background())
+	c.drainCancel = cancel
+	defer cancel()
+
+	// inFlightWindow bounds how many messages can be mid-handling (including
+	// retry backoff) at once, so Start doesn't poll further ahead than the
+	// worker pool can actually keep up with; see WithAutoTune.
+	inFlightWindow := make(chan struct{}, c.concurrency)
+
 	for {
 		select {
 		case <-ctx.Done():
-			c.logger.Info("Consumer stopping")
-			return ctx.Err()
-		default:
-			// Poll for messages with timeout to allow context cancellation
-			msg, err := c.consumer.ReadMessage(pollTimeout)
-			if err != nil {
-				// Timeout is expected when no messages, not an error
-				if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Code() == kafka.ErrTimedOut {
-					continue
-				}
-				c.logger.Error("Error reading message", "error", err)
-				consecutiveErrors++
-				if consecutiveErrors >= maxConsecutiveErrors {
-					return fmt.Errorf("too many consecutive errors (%d), giving up: %w", maxConsecutiveErrors, err)
-				}
+			c.logger.Info("Consumer stopping, draining in-flight handlers")
+			return c.drain()
+		case inFlightWindow <- struct{}{}:
+		}
+
+		// Poll for messages with timeout to allow context cancellation
+		msg, err := c.driver.Poll(pollTimeout)
+		if err != nil {
+			<-inFlightWindow
+			// Timeout is expected when no messages, not an error
+			if errors.Is(err, driver.ErrTimeout) {
 				continue
 			}
+			c.logger.Error("Error reading message", "error", err)
+			consecutiveErrors++
+			if consecutiveErrors >= maxConsecutiveErrors {
+				return fmt.Errorf("too many consecutive errors (%d), giving up: %w", maxConsecutiveErrors, err)
+			}
+			continue
+		}
 
-			// Reset error counter on successful read
-			consecutiveErrors = 0
+		// Reset error counter on successful read
+		consecutiveErrors = 0
+		c.telemetry.recordLag(ctx, c.driver, msg)
 
-			// Parse CloudEvent
-			event, err := c.parseCloudEvent(msg)
-			if err != nil {
-				c.logger.Error("Error parsing CloudEvent", "error", err)
-				continue
-			}
+		// Parse CloudEvent
+		event, err := c.parseCloudEvent(msg)
+		if err != nil {
+			<-inFlightWindow
+			c.logger.Error("Error parsing CloudEvent", "error", err)
+			continue
+		}
 
-			// Call user handler based on signature type
-			if err := c.invokeHandler(event); err != nil {
-				c.logger.Error("Handler error", "error", err, "event_type", event.Type())
-
-				// Check if we should retry using core
-				shouldRetry, retryErr := c.core.ShouldRetry(err.Error(), 1)
-				if retryErr != nil {
-					c.logger.Error("Error checking retry", "error", retryErr)
-					continue
-				}
-
-				if shouldRetry {
-					backoff, backoffErr := c.core.CalculateBackoff(1)
-					if backoffErr != nil {
-						c.logger.Error("Error calculating backoff", "error", backoffErr)
-						continue
-					}
-					c.logger.Warn("Would retry after backoff", "backoff_ms", backoff, "note", "not implemented in PoC")
-				}
+		// Handle the message to completion (including retries and dead-lettering)
+		// before committing its offset. This runs in its own goroutine, decoupled
+		// from ctx, so that a shutdown request doesn't abort a retry backoff or
+		// dead-letter publish mid-flight; see drain. Up to c.concurrency messages
+		// are handled concurrently, gated by inFlightWindow; tracker (registered
+		// here, in poll order) keeps commits on msg's partition safe regardless
+		// of the order the concurrent goroutines finish in.
+		tracker := c.trackerFor(msg)
+		tracker.register(msg)
+
+		c.inFlight.Add(1)
+		go func() {
+			defer c.inFlight.Done()
+			defer func() { <-inFlightWindow }()
+			if err := c.processMessage(handlerCtx, msg, event, tracker); err != nil {
+				c.logger.Error("Giving up on message without committing offset", "error", err, "event_type", event.Type())
 			}
+		}()
+	}
+}
+
+// drain waits up to shutdownTimeout for in-flight handler goroutines to
+// finish, so offsets for messages already picked up still get committed
+// before the consumer closes. If the timeout elapses, it cancels the
+// goroutines' context (interrupting a retry backoff wait) and returns an
+// error without waiting further; Close still fences on them before touching
+// the driver or core, so abandoning the wait here doesn't let Close race
+// with a goroutine still in flight.
+func (c *Consumer) drain() error {
+	done := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		c.logger.Info("In-flight handler finished, shutting down cleanly")
+		return nil
+	case <-time.After(c.shutdownTimeout):
+		c.logger.Warn("Shutdown timeout exceeded, cancelling in-flight handlers", "timeout", c.shutdownTimeout)
+		c.drainCancel()
+		return fmt.Errorf("shutdown timeout of %s exceeded waiting for in-flight handler", c.shutdownTimeout)
+	}
+}
+
+// processMessage invokes the handler for event, retrying on failure per
+// core.ShouldRetry/CalculateBackoff until it succeeds, is dead-lettered, or
+// ctx is cancelled. The consumer offset for msg is only committed once the
+// message has succeeded or been dead-lettered (not when ctx is cancelled), so
+// a crash or shutdown mid-retry replays it rather than losing it.
+func (c *Consumer) processMessage(ctx context.Context, msg *driver.Message, event *cloudevents.Event, tracker *partitionTracker) error {
+	ctx, span := c.telemetry.startSpan(ctx, msg, event)
+	defer span.End()
+
+	var attempt uint32 = 1
+
+	for {
+		handlerStart := time.Now()
+		handlerErr := c.invokeHandler(ctx, event)
+		c.telemetry.handlerDuration.Record(ctx, time.Since(handlerStart).Seconds())
+
+		if handlerErr == nil {
+			return c.advanceCommit(tracker, msg)
+		}
+
+		c.telemetry.handlerErrors.Add(ctx, 1)
+		span.RecordError(handlerErr)
+		c.logger.Error("Handler error", "error", handlerErr, "event_type", event.Type(), "attempt", attempt)
+
+		shouldRetry, retryErr := c.core.ShouldRetry(handlerErr.Error(), attempt)
+		if retryErr != nil {
+			c.logger.Error("Error checking retry", "error", retryErr)
+			shouldRetry = false
+		}
+		if c.maxRetries > 0 && int(attempt) > c.maxRetries {
+			shouldRetry = false
+		}
+
+		if !shouldRetry {
+			span.SetStatus(codes.Error, handlerErr.Error())
+			return c.deadLetter(ctx, msg, event, handlerErr, attempt, tracker)
+		}
+
+		backoff, backoffErr := c.core.CalculateBackoff(attempt)
+		if backoffErr != nil {
+			c.logger.Error("Error calculating backoff", "error", backoffErr)
+			span.SetStatus(codes.Error, backoffErr.Error())
+			return c.deadLetter(ctx, msg, event, handlerErr, attempt, tracker)
+		}
+
+		c.telemetry.retries.Add(ctx, 1)
+		c.logger.Warn("Retrying after backoff", "backoff_ms", backoff, "attempt", attempt, "event_type", event.Type())
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(backoff) * time.Millisecond):
 		}
+
+		attempt++
+	}
+}
+
+// commitOffset synchronously commits the offset of a successfully-handled
+// message. Serialized by commitMu: some drivers (e.g. confluent-kafka-go)
+// don't support concurrent Commit calls, even across partitions.
+func (c *Consumer) commitOffset(msg *driver.Message) error {
+	c.commitMu.Lock()
+	defer c.commitMu.Unlock()
+	return c.driver.Commit(msg)
+}
+
+// advanceCommit marks msg as finished on tracker and commits every message
+// that's now contiguous from the last committed point on msg's partition, in
+// order. See partitionTracker for why this is needed instead of each
+// goroutine committing its own message directly.
+func (c *Consumer) advanceCommit(tracker *partitionTracker, msg *driver.Message) error {
+	for _, m := range tracker.complete(msg) {
+		if err := c.commitOffset(m); err != nil {
+			return fmt.Errorf("failed to commit offset %d on %s/%d: %w", m.Offset, m.Topic, m.Partition, err)
+		}
+	}
+	return nil
+}
+
+// deadLetterEnvelope captures the original message plus why it ended up on the
+// dead-letter topic, so consumers of that topic can inspect and replay it.
+type deadLetterEnvelope struct {
+	Error             string          `json:"error"`
+	Attempts          uint32          `json:"attempts"`
+	OriginalTopic     string          `json:"original_topic"`
+	OriginalPartition int32           `json:"original_partition"`
+	OriginalOffset    int64           `json:"original_offset"`
+	Event             json.RawMessage `json:"event"`
+}
+
+// deadLetter publishes the original event plus failure metadata to the
+// configured dead-letter topic, then commits the message's offset so it is
+// not redelivered. If no dead-letter topic is configured, the message is
+// dropped (and logged) after committing, matching the prior best-effort behavior.
+func (c *Consumer) deadLetter(ctx context.Context, msg *driver.Message, event *cloudevents.Event, cause error, attempts uint32, tracker *partitionTracker) error {
+	if c.deadLetterTopic == "" {
+		c.logger.Error("Exhausted retries with no dead-letter topic configured, dropping message",
+			"error", cause, "attempts", attempts, "event_type", event.Type())
+		return c.advanceCommit(tracker, msg)
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event for dead-lettering: %w", err)
+	}
+
+	envelope := deadLetterEnvelope{
+		Error:             cause.Error(),
+		Attempts:          attempts,
+		OriginalTopic:     msg.Topic,
+		OriginalPartition: msg.Partition,
+		OriginalOffset:    msg.Offset,
+		Event:             eventJSON,
 	}
+	envelopeJSON, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to serialize dead-letter envelope: %w", err)
+	}
+
+	topic := c.deadLetterTopic
+	if err := c.driver.Produce(topic, []byte(event.ID()), envelopeJSON); err != nil {
+		return fmt.Errorf("failed to produce dead-letter message: %w", err)
+	}
+	c.telemetry.dlqCount.Add(ctx, 1)
+
+	c.logger.Warn("Dead-lettered message", "topic", topic, "event_type", event.Type(), "attempts", attempts)
+	return c.advanceCommit(tracker, msg)
 }
 
 // invokeHandler calls the user's handler function and handles output events
-func (c *Consumer) invokeHandler(event *cloudevents.Event) error {
+func (c *Consumer) invokeHandler(ctx context.Context, event *cloudevents.Event) error {
 	// Prepare arguments
-	args := []reflect.Value{reflect.ValueOf(*event)}
+	var args []reflect.Value
+	if c.handlerSig == handlerSignatureSimpleCtx {
+		args = []reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(*event)}
+	} else {
+		args = []reflect.Value{reflect.ValueOf(*event)}
+	}
 
 	// Call handler
 	results := c.handlerValue.Call(args)
 
 	// Handle results based on handler signature
 	switch c.handlerSig {
-	case handlerSignatureSimple:
-		// func(Event) error
+	case handlerSignatureSimple, handlerSignatureSimpleCtx:
+		// func(Event) error, or func(context.Context, Event) error
 		if !results[0].IsNil() {
 			return results[0].Interface().(error)
 		}
@@ -234,19 +525,39 @@ func (c *Consumer) invokeHandler(event *cloudevents.Event) error {
 		// Handle output event if present
 		if !results[0].IsNil() {
 			outputEvent := results[0].Interface().(*cloudevents.Event)
-			if err := c.publishOutputEvent(outputEvent); err != nil {
+			if err := c.publishOutputEvent(ctx, outputEvent); err != nil {
 				return fmt.Errorf("failed to publish output event: %w", err)
 			}
 		}
 		return nil
 
+	case handlerSignatureBatchOutput:
+		// func(Event) ([]*Event, error)
+		// Check error first
+		if !results[1].IsNil() {
+			return results[1].Interface().(error)
+		}
+
+		// Route each returned event independently so a single input can fan out
+		// to several (possibly differently-routed) output events.
+		outputEvents := results[0].Interface().([]*cloudevents.Event)
+		for _, outputEvent := range outputEvents {
+			if outputEvent == nil {
+				continue
+			}
+			if err := c.publishOutputEvent(ctx, outputEvent); err != nil {
+				return fmt.Errorf("failed to publish output event %s: %w", outputEvent.ID(), err)
+			}
+		}
+		return nil
+
 	default:
 		return fmt.Errorf("unknown handler signature")
 	}
 }
 
 // publishOutputEvent routes and publishes an output event
-func (c *Consumer) publishOutputEvent(event *cloudevents.Event) error {
+func (c *Consumer) publishOutputEvent(ctx context.Context, event *cloudevents.Event) error {
 	// Serialize event to JSON for routing
 	eventJSON, err := json.Marshal(event)
 	if err != nil {
@@ -267,27 +578,30 @@ func (c *Consumer) publishOutputEvent(event *cloudevents.Event) error {
 	// Handle different destination types
 	switch dest.Type {
 	case DestinationKafka:
+		if sink, ok := c.sinks[DestinationKafka]; ok {
+			if err := sink.Publish(ctx, dest, event); err != nil {
+				return fmt.Errorf("failed to publish to Kafka sink: %w", err)
+			}
+			return nil
+		}
 		return c.publishToKafka(event, dest)
 	case DestinationDiscard:
 		c.logger.Info("Discarding output event", "event_type", event.Type())
 		return nil
-	case DestinationHTTP, DestinationRabbitMQ:
-		// TODO: Implement HTTP and RabbitMQ publishing
-		c.logger.Warn("Destination type not yet implemented, discarding event",
-			"dest_type", dest.Type,
-			"event_type", event.Type())
-		return nil
 	default:
-		return fmt.Errorf("unknown destination type: %d", dest.Type)
+		sink, ok := c.sinks[dest.Type]
+		if !ok {
+			return fmt.Errorf("unknown destination type: %d", dest.Type)
+		}
+		if err := sink.Publish(ctx, dest, event); err != nil {
+			return fmt.Errorf("failed to publish to %v sink: %w", dest.Type, err)
+		}
+		return nil
 	}
 }
 
 // publishToKafka publishes an event to a Kafka topic
 func (c *Consumer) publishToKafka(event *cloudevents.Event, dest *OutputDestination) error {
-	if c.producer == nil {
-		return fmt.Errorf("kafka producer not initialized")
-	}
-
 	// Serialize event
 	eventJSON, err := json.Marshal(event)
 	if err != nil {
@@ -296,13 +610,7 @@ func (c *Consumer) publishToKafka(event *cloudevents.Event, dest *OutputDestinat
 
 	// Produce to Kafka
 	topic := dest.Target
-	err = c.producer.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
-		Value:          eventJSON,
-		Key:            []byte(event.ID()),
-	}, nil)
-
-	if err != nil {
+	if err := c.driver.Produce(topic, []byte(event.ID()), eventJSON); err != nil {
 		return fmt.Errorf("failed to produce message: %w", err)
 	}
 
@@ -310,8 +618,8 @@ func (c *Consumer) publishToKafka(event *cloudevents.Event, dest *OutputDestinat
 	return nil
 }
 
-// parseCloudEvent converts Kafka message to CloudEvent
-func (c *Consumer) parseCloudEvent(msg *kafka.Message) (*cloudevents.Event, error) {
+// parseCloudEvent converts a driver Message to a CloudEvent
+func (c *Consumer) parseCloudEvent(msg *driver.Message) (*cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 
 	// Try to parse as structured CloudEvent (JSON)
@@ -330,15 +638,24 @@ func (c *Consumer) parseCloudEvent(msg *kafka.Message) (*cloudevents.Event, erro
 	return &event, nil
 }
 
-// Close releases resources
+// Close releases resources. It waits for any handler goroutines started by
+// Start to finish first, even if a prior drain already gave up on a clean
+// shutdown and returned an error: otherwise a goroutine still calling
+// c.driver/c.core methods could race with this closing them out from under
+// it.
 func (c *Consumer) Close() error {
-	if c.producer != nil {
-		c.producer.Flush(5000)
-		c.producer.Close()
+	c.inFlight.Wait()
+
+	for destType, sink := range c.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				c.logger.Error("Error closing sink", "dest_type", destType, "error", err)
+			}
+		}
 	}
-	if c.consumer != nil {
-		if err := c.consumer.Close(); err != nil {
-			return fmt.Errorf("failed to close Kafka consumer: %w", err)
+	if c.driver != nil {
+		if err := c.driver.Close(); err != nil {
+			return fmt.Errorf("failed to close Kafka driver: %w", err)
 		}
 	}
 	if c.core != nil {
@@ -0,0 +1,191 @@
+// Package confluent implements driver.ConsumerDriver using
+// confluent-kafka-go (CGO + librdkafka). It is the SDK's default driver; see
+// package franz for a pure-Go alternative.
+package confluent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+)
+
+// Driver implements driver.ConsumerDriver on top of confluent-kafka-go.
+type Driver struct {
+	cfg      driver.Config
+	consumer *kafka.Consumer
+	producer *kafka.Producer
+}
+
+// New creates a Kafka consumer configured from cfg. Call Subscribe to start
+// consuming cfg.Topic. Auto-commit is disabled: offsets are committed
+// explicitly through Commit once a message has been handled, so a crash
+// mid-retry replays the message instead of silently losing it.
+func New(cfg driver.Config) (*Driver, error) {
+	consumerConfig := ClientConfig(cfg)
+	consumerConfig["group.id"] = cfg.Group
+	consumerConfig["auto.offset.reset"] = "earliest"
+	consumerConfig["enable.auto.commit"] = false
+
+	consumer, err := kafka.NewConsumer(&consumerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
+	}
+
+	return &Driver{cfg: cfg, consumer: consumer}, nil
+}
+
+// Subscribe subscribes to cfg.Topic with a rebalance callback that seeks
+// every newly-assigned partition to the beginning, so the consumer replays
+// all messages rather than picking up wherever the group last left off.
+func (d *Driver) Subscribe() error {
+	rebalanceCb := func(c *kafka.Consumer, event kafka.Event) error {
+		switch e := event.(type) {
+		case kafka.AssignedPartitions:
+			for i := range e.Partitions {
+				e.Partitions[i].Offset = kafka.OffsetBeginning
+			}
+			return c.Assign(e.Partitions)
+		case kafka.RevokedPartitions:
+			return c.Unassign()
+		}
+		return nil
+	}
+
+	if err := d.consumer.Subscribe(d.cfg.Topic, rebalanceCb); err != nil {
+		return fmt.Errorf("failed to subscribe to topic %s: %w", d.cfg.Topic, err)
+	}
+	return nil
+}
+
+// Poll waits up to timeout for the next message.
+func (d *Driver) Poll(timeout time.Duration) (*driver.Message, error) {
+	msg, err := d.consumer.ReadMessage(timeout)
+	if err != nil {
+		if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Code() == kafka.ErrTimedOut {
+			return nil, driver.ErrTimeout
+		}
+		return nil, err
+	}
+
+	return &driver.Message{
+		Topic:     *msg.TopicPartition.Topic,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Key:       msg.Key,
+		Value:     msg.Value,
+	}, nil
+}
+
+// Commit commits the offset of a successfully-handled message.
+func (d *Driver) Commit(msg *driver.Message) error {
+	topic := msg.Topic
+	if _, err := d.consumer.CommitMessage(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: msg.Partition,
+			Offset:    kafka.Offset(msg.Offset),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to commit offset: %w", err)
+	}
+	return nil
+}
+
+// Produce publishes value (with key) to topic. The underlying producer is
+// created lazily on first use, since not every consumer produces output.
+func (d *Driver) Produce(topic string, key, value []byte) error {
+	if d.producer == nil {
+		producerConfig := ClientConfig(d.cfg)
+		producer, err := kafka.NewProducer(&producerConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create Kafka producer: %w", err)
+		}
+		d.producer = producer
+	}
+
+	if err := d.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          value,
+		Key:            key,
+	}, nil); err != nil {
+		return fmt.Errorf("failed to produce message: %w", err)
+	}
+	return nil
+}
+
+// Lag implements driver.LagReporter using librdkafka's locally-cached
+// watermark offsets, so it doesn't incur a broker round-trip per message.
+func (d *Driver) Lag(msg *driver.Message) (int64, error) {
+	_, high, err := d.consumer.GetWatermarkOffsets(msg.Topic, msg.Partition)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get watermark offsets: %w", err)
+	}
+	return high - (msg.Offset + 1), nil
+}
+
+// Close releases the consumer and, if one was created, the producer.
+func (d *Driver) Close() error {
+	if d.producer != nil {
+		d.producer.Flush(5000)
+		d.producer.Close()
+	}
+	if d.consumer != nil {
+		if err := d.consumer.Close(); err != nil {
+			return fmt.Errorf("failed to close Kafka consumer: %w", err)
+		}
+	}
+	return nil
+}
+
+// ClientConfig builds the librdkafka ConfigMap entries shared by both the
+// consumer and producer: the broker address plus whatever auth, TLS, and
+// tunables cfg carries. Exported so producer-only callers outside this
+// package (e.g. pkg/sink/kafka, which produces to clusters other than the
+// one the Consumer itself reads from) can build a config without
+// duplicating this mapping.
+func ClientConfig(cfg driver.Config) kafka.ConfigMap {
+	cm := kafka.ConfigMap{
+		"bootstrap.servers": cfg.Broker,
+	}
+
+	if cfg.SecurityProtocol != "" {
+		cm["security.protocol"] = cfg.SecurityProtocol
+	}
+	if cfg.SASLMechanism != "" {
+		cm["sasl.mechanism"] = cfg.SASLMechanism
+	}
+	if cfg.SASLUsername != "" {
+		cm["sasl.username"] = cfg.SASLUsername
+	}
+	if cfg.SASLPassword != "" {
+		cm["sasl.password"] = cfg.SASLPassword
+	}
+
+	if cfg.TLSCAFile != "" {
+		cm["ssl.ca.location"] = cfg.TLSCAFile
+	}
+	if cfg.TLSCertFile != "" {
+		cm["ssl.certificate.location"] = cfg.TLSCertFile
+	}
+	if cfg.TLSKeyFile != "" {
+		cm["ssl.key.location"] = cfg.TLSKeyFile
+	}
+	if cfg.TLSInsecureSkipVerify {
+		cm["enable.ssl.certificate.verification"] = false
+	}
+
+	if cfg.SessionTimeoutMs != 0 {
+		cm["session.timeout.ms"] = cfg.SessionTimeoutMs
+	}
+	if cfg.MaxPollIntervalMs != 0 {
+		cm["max.poll.interval.ms"] = cfg.MaxPollIntervalMs
+	}
+	if cfg.IsolationLevel != "" {
+		cm["isolation.level"] = cfg.IsolationLevel
+	}
+
+	return cm
+}
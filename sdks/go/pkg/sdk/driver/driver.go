@@ -0,0 +1,95 @@
+// Package driver abstracts the Kafka client library backing sdk.Consumer, so
+// the SDK isn't hard-wired to confluent-kafka-go (which requires
+// CGO/librdkafka and complicates static, musl, and WASM builds). Types here
+// intentionally don't import the sdk package, so driver implementations can
+// be swapped in without pulling the whole SDK (or any particular Kafka
+// client) into the dependency graph.
+package driver
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTimeout is returned by Poll when no message arrived within the timeout.
+var ErrTimeout = errors.New("driver: poll timeout")
+
+// Message is a driver-agnostic view of a consumed Kafka record.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+}
+
+// Config carries what a driver needs to connect to and authenticate against
+// a Kafka cluster. It mirrors sdk.KafkaConfig field-for-field without
+// importing it, so that package doesn't become a dependency of every driver.
+type Config struct {
+	Broker string
+	Topic  string
+	Group  string
+
+	// SecurityProtocol is one of "PLAINTEXT" (default), "SSL",
+	// "SASL_PLAINTEXT", or "SASL_SSL".
+	SecurityProtocol string
+
+	// SASLMechanism is one of "PLAIN", "SCRAM-SHA-256", or "SCRAM-SHA-512".
+	// Only meaningful when SecurityProtocol starts with "SASL_".
+	SASLMechanism string
+	SASLUsername  string
+	SASLPassword  string
+
+	TLSCAFile   string
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSInsecureSkipVerify disables broker certificate verification. Only
+	// ever meant for local development against self-signed brokers.
+	TLSInsecureSkipVerify bool
+
+	// SessionTimeoutMs is the consumer group session timeout in
+	// milliseconds. Zero uses the driver's default.
+	SessionTimeoutMs int
+	// MaxPollIntervalMs is the maximum time between polls before the group
+	// considers the consumer dead, in milliseconds. Zero uses the driver's
+	// default.
+	MaxPollIntervalMs int
+	// IsolationLevel is e.g. "read_committed". Empty uses the driver's
+	// default ("read_uncommitted").
+	IsolationLevel string
+}
+
+// ConsumerDriver abstracts a Kafka client library.
+type ConsumerDriver interface {
+	// Subscribe subscribes to Config.Topic. A partition with no committed
+	// offset is consumed from the beginning; whether a partition with a
+	// committed offset resumes from it depends on the implementation (the
+	// confluent driver always restarts from the beginning, the franz driver
+	// resumes).
+	Subscribe() error
+
+	// Poll waits up to timeout for the next message. It returns ErrTimeout
+	// if none arrived in that window.
+	Poll(timeout time.Duration) (*Message, error)
+
+	// Commit commits the offset of a successfully-handled message.
+	Commit(msg *Message) error
+
+	// Produce publishes value (with key) to topic.
+	Produce(topic string, key, value []byte) error
+
+	// Close releases the driver's connections.
+	Close() error
+}
+
+// LagReporter is implemented by drivers that can report how far behind the
+// latest offset a just-polled message was, for consumer.lag observability.
+// It's optional: not every client library exposes watermark offsets as
+// cheaply, so callers should type-assert a ConsumerDriver against this
+// interface rather than requiring it.
+type LagReporter interface {
+	// Lag returns the number of messages still unconsumed on msg's
+	// partition as of when msg was polled.
+	Lag(msg *Message) (int64, error)
+}
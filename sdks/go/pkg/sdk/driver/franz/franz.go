@@ -0,0 +1,163 @@
+// Package franz implements driver.ConsumerDriver using franz-go, a pure-Go
+// Kafka client. Unlike the default confluent driver, it requires no CGO or
+// librdkafka, which simplifies static binaries and cross-compilation for
+// Knative func images (including WASM targets). Select it with
+// sdk.WithConsumerDriver.
+package franz
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+)
+
+// Driver implements driver.ConsumerDriver on top of franz-go.
+type Driver struct {
+	cfg    driver.Config
+	client *kgo.Client
+
+	// buffered holds records from the most recent PollFetches call that
+	// haven't been handed out by Poll yet. franz-go advances its in-memory
+	// consume cursor past every record in a fetch as soon as it's returned,
+	// so none of these can be re-fetched: they must be drained here before
+	// polling for more.
+	buffered []*kgo.Record
+}
+
+// New creates a Kafka client configured from cfg. Call Subscribe to start
+// consuming cfg.Topic. Auto-commit is disabled: offsets are committed
+// explicitly through Commit once a message has been handled, so a crash
+// mid-retry replays the message instead of silently losing it.
+func New(cfg driver.Config) (*Driver, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Broker),
+		kgo.ConsumerGroup(cfg.Group),
+		kgo.ConsumeTopics(cfg.Topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.DisableAutoCommit(),
+	}
+
+	if mechanism := saslMechanism(cfg); mechanism != nil {
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	if tlsCfg := tlsConfig(cfg); tlsCfg != nil {
+		opts = append(opts, kgo.DialTLSConfig(tlsCfg))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+
+	return &Driver{cfg: cfg, client: client}, nil
+}
+
+// Subscribe is a no-op: franz-go subscribes to cfg.Topic as part of client
+// construction, so there's nothing left to do here. A newly-assigned
+// partition resumes from the group's committed offset; only a partition with
+// no committed offset starts from the beginning (kgo.ConsumeResetOffset).
+func (d *Driver) Subscribe() error {
+	return nil
+}
+
+// Poll waits up to timeout for the next message. If a previous fetch
+// returned more than one record, the rest are handed out here first, one per
+// call, before polling the broker again.
+func (d *Driver) Poll(timeout time.Duration) (*driver.Message, error) {
+	if len(d.buffered) == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		fetches := d.client.PollFetches(ctx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			if ctx.Err() != nil {
+				return nil, driver.ErrTimeout
+			}
+			return nil, fmt.Errorf("failed to fetch messages: %w", errs[0].Err)
+		}
+		fetches.EachRecord(func(r *kgo.Record) {
+			d.buffered = append(d.buffered, r)
+		})
+	}
+	if len(d.buffered) == 0 {
+		return nil, driver.ErrTimeout
+	}
+
+	r := d.buffered[0]
+	d.buffered = d.buffered[1:]
+	return &driver.Message{
+		Topic:     r.Topic,
+		Partition: r.Partition,
+		Offset:    r.Offset,
+		Key:       r.Key,
+		Value:     r.Value,
+	}, nil
+}
+
+// Commit commits the offset of a successfully-handled message.
+func (d *Driver) Commit(msg *driver.Message) error {
+	if err := d.client.CommitRecords(context.Background(), &kgo.Record{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+	}); err != nil {
+		return fmt.Errorf("failed to commit offset: %w", err)
+	}
+	return nil
+}
+
+// Produce publishes value (with key) to topic.
+func (d *Driver) Produce(topic string, key, value []byte) error {
+	results := d.client.ProduceSync(context.Background(), &kgo.Record{
+		Topic: topic,
+		Key:   key,
+		Value: value,
+	})
+	if err := results.FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce message: %w", err)
+	}
+	return nil
+}
+
+// Close releases the client's connections.
+func (d *Driver) Close() error {
+	d.client.Close()
+	return nil
+}
+
+// saslMechanism builds a SASL mechanism from cfg, or returns nil if cfg
+// doesn't configure one.
+func saslMechanism(cfg driver.Config) sasl.Mechanism {
+	if cfg.SASLMechanism == "" {
+		return nil
+	}
+
+	switch cfg.SASLMechanism {
+	case "SCRAM-SHA-256":
+		return scram.Auth{User: cfg.SASLUsername, Pass: cfg.SASLPassword}.AsSha256Mechanism()
+	case "SCRAM-SHA-512":
+		return scram.Auth{User: cfg.SASLUsername, Pass: cfg.SASLPassword}.AsSha512Mechanism()
+	default:
+		return plain.Auth{User: cfg.SASLUsername, Pass: cfg.SASLPassword}.AsMechanism()
+	}
+}
+
+// tlsConfig builds a *tls.Config from cfg, or returns nil if cfg doesn't
+// enable TLS. Unlike the confluent driver, cfg's CA/cert/key files are not
+// loaded: the returned Config uses the system root CAs and no client
+// certificate, and only TLSInsecureSkipVerify is applied.
+func tlsConfig(cfg driver.Config) *tls.Config {
+	if cfg.SecurityProtocol != "SSL" && cfg.SecurityProtocol != "SASL_SSL" {
+		return nil
+	}
+	return &tls.Config{InsecureSkipVerify: cfg.TLSInsecureSkipVerify} //nolint:gosec // opt-in via config
+}
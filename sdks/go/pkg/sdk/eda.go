@@ -6,9 +6,8 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
-	"path/filepath"
-	"runtime"
 	"syscall"
+	"time"
 )
 
 // RunWithCore starts the EDA consumer with an explicit core instance
@@ -21,51 +20,40 @@ func RunWithCore[H Handler](core Core, handler H, opts ...Option) error {
 	// Apply options
 	options := applyOptions(opts)
 
-	// Try to load routing configuration from the caller's directory
-	// Walk up the call stack to find the first caller outside the SDK
-	var callerDir string
-	for i := 1; i < 10; i++ {
-		_, file, _, ok := runtime.Caller(i)
-		if !ok {
-			break
-		}
-		// Skip SDK internal files
-		if filepath.Base(filepath.Dir(file)) != "sdk" &&
-			filepath.Base(filepath.Dir(file)) != "ffi" &&
-			filepath.Base(filepath.Dir(file)) != "wasm" {
-			callerDir = filepath.Dir(file)
-			break
-		}
-	}
+	// Setup signal handling for graceful shutdown
+	ctx, cancel := context.WithCancel(options.Context)
+	defer cancel()
 
-	if callerDir != "" {
-		routingConfigPath := filepath.Join(callerDir, "routing.yaml")
-		if _, err := os.Stat(routingConfigPath); err == nil {
-			slog.Info("Loading routing configuration", "path", routingConfigPath)
-			if err := core.LoadRoutingConfig(routingConfigPath); err != nil {
-				return fmt.Errorf("failed to load routing config: %w", err)
-			}
-		}
+	// Load routing config (see configureRouting for the discovery order) and,
+	// for file-based sources, start watching it for hot-reload until ctx is
+	// cancelled.
+	if err := configureRouting(ctx, core, options); err != nil {
+		return err
 	}
 
 	// Create consumer (NewConsumer accepts interface{} and does runtime type checking)
-	consumer, err := NewConsumer(core, handler)
+	consumer, err := NewConsumer(core, handler, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create consumer: %w", err)
 	}
 	defer consumer.Close()
 
-	// Setup signal handling for graceful shutdown
-	ctx, cancel := context.WithCancel(options.Context)
-	defer cancel()
-
-	sigChan := make(chan os.Signal, 1)
+	sigChan := make(chan os.Signal, 2)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		<-sigChan
-		slog.Info("Shutting down...")
+		slog.Info("Shutting down gracefully, send another signal to force-abort...", "timeout", options.ShutdownTimeout)
 		cancel()
+
+		select {
+		case <-sigChan:
+			slog.Warn("Second signal received, forcing immediate exit")
+			os.Exit(1)
+		case <-time.After(options.ShutdownTimeout + time.Second):
+			// consumer.Start should have returned by now via its own timeout;
+			// this is a backstop in case it hasn't.
+		}
 	}()
 
 	// Start consuming
@@ -0,0 +1,27 @@
+package sdk
+
+import "github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+
+// toDriverConfig converts the KafkaConfig returned by Core into the
+// driver-agnostic Config consumed by a driver.ConsumerDriver.
+func toDriverConfig(config *KafkaConfig) driver.Config {
+	return driver.Config{
+		Broker: config.Broker,
+		Topic:  config.Topic,
+		Group:  config.Group,
+
+		SecurityProtocol: config.Auth.SecurityProtocol,
+		SASLMechanism:    config.Auth.Mechanism,
+		SASLUsername:     config.Auth.Username,
+		SASLPassword:     config.Auth.Password,
+
+		TLSCAFile:             config.TLS.CAFile,
+		TLSCertFile:           config.TLS.CertFile,
+		TLSKeyFile:            config.TLS.KeyFile,
+		TLSInsecureSkipVerify: config.TLS.InsecureSkipVerify,
+
+		SessionTimeoutMs:  config.Tuning.SessionTimeoutMs,
+		MaxPollIntervalMs: config.Tuning.MaxPollIntervalMs,
+		IsolationLevel:    config.Tuning.IsolationLevel,
+	}
+}
@@ -1,10 +1,32 @@
 package sdk
 
-import "context"
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+)
 
 // CoreConstructor is a function that creates a Core instance
 type CoreConstructor func() (Core, error)
 
+// ClusterResolver maps a cluster name (sdk.OutputDestination.Cluster) to the
+// broker/auth config needed to connect to it, for routing output events to a
+// Kafka cluster other than the one the Consumer itself reads from. It has
+// the same shape as pkg/sink/kafka.ClusterResolver; the two aren't the same
+// type because pkg/sink/kafka imports pkg/sdk and a dependency the other way
+// would cycle. See WithClusterResolver.
+type ClusterResolver func(cluster string) (driver.Config, error)
+
+// defaultShutdownTimeout is how long Consumer.Start waits for an in-flight
+// handler to finish once shutdown has been requested.
+const defaultShutdownTimeout = 30 * time.Second
+
 // Options holds configuration for the consumer
 type Options struct {
 	// Context for the consumer (if nil, a default context will be created)
@@ -13,8 +35,72 @@ type Options struct {
 	// CoreConstructor creates the Core instance (if nil, must be provided externally)
 	CoreConstructor CoreConstructor
 
-	// Additional options can be added here in the future
-	// e.g., custom logger, error handlers, etc.
+	// DeadLetterTopic is the Kafka topic that terminally-failed messages are
+	// published to. If empty, terminally-failed messages are dropped (and logged).
+	DeadLetterTopic string
+
+	// MaxRetries caps the number of handler re-invocations for a failing message,
+	// regardless of what core.ShouldRetry reports. Zero means no SDK-side cap;
+	// retries are governed entirely by core.ShouldRetry.
+	MaxRetries int
+
+	// SinkRegistry overrides/extends the Sinks used for output destinations.
+	// Entries here take precedence over the built-in defaults; a
+	// DestinationKafka entry replaces producing on the Consumer's own cluster.
+	SinkRegistry SinkRegistry
+
+	// ShutdownTimeout bounds how long Start waits, once ctx is cancelled, for
+	// the in-flight handler invocation to finish before giving up on a clean
+	// shutdown. Defaults to defaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	// ConsumerDriver overrides the Kafka client backing the consumer. If nil,
+	// NewConsumer builds the default confluent-kafka-go driver from the
+	// Core's KafkaConfig. Set this to select a different driver, e.g.
+	// driver/franz for CGO-free builds, or a test double.
+	ConsumerDriver driver.ConsumerDriver
+
+	// Logger overrides the consumer's slog.Logger. If nil, NewConsumer builds
+	// a JSON handler writing to stdout. Set this to inject a logfmt handler,
+	// a test-capturing handler, or a differently-configured JSON one.
+	Logger *slog.Logger
+
+	// TracerProvider builds the tracer used for the per-message span. If
+	// nil, otel.GetTracerProvider() is used (a no-op until the process
+	// registers a real one).
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider builds the meter used for consumer metrics. If nil,
+	// otel.GetMeterProvider() is used (a no-op until the process registers a
+	// real one).
+	MeterProvider metric.MeterProvider
+
+	// RoutingConfigPath explicitly selects the routing.yaml to load, taking
+	// priority over the EDA_ROUTING_CONFIG env var and the executable- and
+	// XDG-relative defaults. Ignored if RoutingConfigFS is set.
+	RoutingConfigPath string
+
+	// RoutingConfigFS, if set, loads routing config from RoutingConfigFSPath
+	// within this filesystem instead of from a path on disk, so images can
+	// ship a config embedded in the binary. The content is copied to a temp
+	// file in os.TempDir before loading, so a writable temp directory is still
+	// needed. This takes priority over RoutingConfigPath.
+	RoutingConfigFS     fs.FS
+	RoutingConfigFSPath string
+
+	// AutoTune, when set via WithAutoTune, sizes GOMAXPROCS, the Go runtime's
+	// soft memory limit, and the handler worker pool / in-flight message
+	// window to the process's cgroup CPU/memory limits instead of the
+	// host's. See WithAutoTune.
+	AutoTune bool
+
+	// ClusterResolver, when set via WithClusterResolver, lets cross-cluster
+	// DestinationKafka output events resolve their target cluster's broker
+	// config by name instead of requiring a hand-built
+	// WithSinkRegistry{DestinationKafka: kafka.New(resolver)}. Entrypoints
+	// that support it (currently pkg/ffi.Run) wire it into SinkRegistry
+	// automatically, unless DestinationKafka is already registered there.
+	ClusterResolver ClusterResolver
 }
 
 // Option is a function that modifies Options
@@ -34,10 +120,131 @@ func WithCoreConstructor(constructor CoreConstructor) Option {
 	}
 }
 
+// WithDeadLetterTopic configures the Kafka topic that messages are published to
+// once core.ShouldRetry reports no further retries (or MaxRetries is exhausted).
+func WithDeadLetterTopic(topic string) Option {
+	return func(o *Options) {
+		o.DeadLetterTopic = topic
+	}
+}
+
+// WithMaxRetries caps the number of handler re-invocations for a failing message.
+// Pass 0 (the default) to rely solely on core.ShouldRetry.
+func WithMaxRetries(maxRetries int) Option {
+	return func(o *Options) {
+		o.MaxRetries = maxRetries
+	}
+}
+
+// WithSinkRegistry registers Sinks for output destination types, e.g. to
+// override the default HTTP/RabbitMQ sinks, replace the built-in Kafka
+// handling with a DestinationKafka sink, or add support for a new
+// DestinationType.
+func WithSinkRegistry(registry SinkRegistry) Option {
+	return func(o *Options) {
+		o.SinkRegistry = registry
+	}
+}
+
+// WithClusterResolver configures cross-cluster DestinationKafka routing by
+// name, so routing.yaml entries that target a cluster other than the one
+// the Consumer reads from work without a manually-registered Sink. See
+// ClusterResolver and Options.ClusterResolver.
+func WithClusterResolver(resolver ClusterResolver) Option {
+	return func(o *Options) {
+		o.ClusterResolver = resolver
+	}
+}
+
+// WithShutdownTimeout sets how long Start waits for an in-flight handler to
+// finish once shutdown has been requested before abandoning it.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.ShutdownTimeout = timeout
+	}
+}
+
+// WithConsumerDriver selects the Kafka client backing the consumer, in place
+// of the default confluent-kafka-go driver. The driver must already be
+// constructed and ready to Subscribe; NewConsumer calls Subscribe on it
+// exactly once.
+func WithConsumerDriver(d driver.ConsumerDriver) Option {
+	return func(o *Options) {
+		o.ConsumerDriver = d
+	}
+}
+
+// WithLogger sets the logger used by the consumer, in place of the default
+// JSON handler writing to stdout.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider used to create the
+// per-message span.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *Options) {
+		o.TracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry MeterProvider used to record
+// consumer metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *Options) {
+		o.MeterProvider = mp
+	}
+}
+
+// WithRoutingConfig explicitly selects the routing.yaml to load, in place of
+// the EDA_ROUTING_CONFIG env var and the executable- and XDG-relative
+// defaults.
+func WithRoutingConfig(path string) Option {
+	return func(o *Options) {
+		o.RoutingConfigPath = path
+	}
+}
+
+// WithRoutingConfigFS loads routing config from path within fsys instead of
+// from a path on disk, so images can ship a config embedded in the binary.
+// The content is copied to a temp file in os.TempDir before loading, so a
+// writable temp directory is still needed. It takes priority over
+// WithRoutingConfig and the env var/executable/XDG defaults, and doesn't
+// support hot-reload.
+func WithRoutingConfigFS(fsys fs.FS, path string) Option {
+	return func(o *Options) {
+		o.RoutingConfigFS = fsys
+		o.RoutingConfigFSPath = path
+	}
+}
+
+// WithAutoTune enables cgroup-aware resource tuning: on Linux, NewConsumer
+// reads the process's cgroup (v2, falling back to v1) CPU quota and memory
+// limit and uses them to set GOMAXPROCS and runtime/debug.SetMemoryLimit,
+// rather than letting the Go runtime size itself off the host's resources as
+// seen by the container. The resulting CPU count also becomes the size of
+// the handler worker pool and the consumer's in-flight message window, so a
+// function pod given e.g. 500m CPU doesn't over-commit goroutines the
+// scheduler can't actually run concurrently.
+//
+// Tuning is skipped wherever the user has already taken control: the
+// GOMAXPROCS and GOMEMLIMIT environment variables, if set, are left alone.
+// It's also a no-op on non-Linux platforms, and degrades gracefully (falling
+// back to host values) when cgroup files don't exist or aren't readable, as
+// in rootless containers.
+func WithAutoTune() Option {
+	return func(o *Options) {
+		o.AutoTune = true
+	}
+}
+
 // applyOptions applies the given options and returns the final Options
 func applyOptions(opts []Option) *Options {
 	options := &Options{
-		Context: context.Background(),
+		Context:         context.Background(),
+		ShutdownTimeout: defaultShutdownTimeout,
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,160 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// configureRouting loads routing.yaml into core, from whichever source
+// options configures, and (for file-based sources) starts a hot-reload
+// watcher that re-loads it whenever it changes, until ctx is cancelled. It is
+// a no-op if no source resolves to anything.
+func configureRouting(ctx context.Context, core Core, options *Options) error {
+	if options.RoutingConfigFS != nil {
+		return loadRoutingConfigFromFS(core, options.RoutingConfigFS, options.RoutingConfigFSPath)
+	}
+
+	path, err := resolveRoutingConfigPath(options)
+	if err != nil {
+		return fmt.Errorf("failed to resolve routing config path: %w", err)
+	}
+	if path == "" {
+		return nil
+	}
+
+	slog.Info("Loading routing configuration", "path", path)
+	if err := core.LoadRoutingConfig(path); err != nil {
+		return fmt.Errorf("failed to load routing config: %w", err)
+	}
+
+	watchRoutingConfig(ctx, core, path)
+	return nil
+}
+
+// resolveRoutingConfigPath finds routing.yaml in priority order: an explicit
+// WithRoutingConfig path, the EDA_ROUTING_CONFIG env var, a routing.yaml next
+// to the running executable, or one under $XDG_CONFIG_HOME/func-eda (falling
+// back to ~/.config/func-eda). The explicit path and the env var are returned
+// as given, without checking that the file exists; the other two are used
+// only if the file exists. It returns "" if no source applies, which isn't
+// an error: routing config is optional.
+func resolveRoutingConfigPath(options *Options) (string, error) {
+	if options.RoutingConfigPath != "" {
+		return options.RoutingConfigPath, nil
+	}
+
+	if path := os.Getenv("EDA_ROUTING_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	if exe, err := os.Executable(); err == nil {
+		if path := filepath.Join(filepath.Dir(exe), "routing.yaml"); fileExists(path) {
+			return path, nil
+		}
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	if path := filepath.Join(configHome, "func-eda", "routing.yaml"); fileExists(path) {
+		return path, nil
+	}
+
+	return "", nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// loadRoutingConfigFromFS loads routing config from fsys, so images can ship
+// it embedded in the binary. core.LoadRoutingConfig takes a path, so the
+// content is copied to a temp file in os.TempDir first, which needs a
+// writable temp directory; hot-reload isn't supported for this source since
+// an embedded FS doesn't change at runtime.
+func loadRoutingConfigFromFS(core Core, fsys fs.FS, path string) error {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open routing config %s: %w", path, err)
+	}
+	defer f.Close()
+
+	tmp, err := os.CreateTemp("", "routing-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temp routing config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, f); err != nil {
+		return fmt.Errorf("failed to copy routing config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp routing config: %w", err)
+	}
+
+	slog.Info("Loading routing configuration", "path", path, "source", "fs")
+	if err := core.LoadRoutingConfig(tmp.Name()); err != nil {
+		return fmt.Errorf("failed to load routing config: %w", err)
+	}
+	return nil
+}
+
+// watchRoutingConfig reloads core's routing config whenever path changes,
+// until ctx is cancelled. It watches path's parent directory rather than the
+// file itself, since editors and config-management tools often replace a
+// file via rename rather than writing it in place, which a single-file watch
+// would miss. Failure to start the watcher only disables hot-reload; it
+// doesn't fail startup, since the config was already loaded successfully.
+func watchRoutingConfig(ctx context.Context, core Core, path string) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Warn("Failed to start routing config watcher, hot-reload disabled", "error", err)
+		return
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		slog.Warn("Failed to watch routing config directory, hot-reload disabled", "path", path, "error", err)
+		watcher.Close()
+		return
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				slog.Info("Routing configuration changed, reloading", "path", path)
+				if err := core.LoadRoutingConfig(path); err != nil {
+					slog.Error("Failed to reload routing config", "path", path, "error", err)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Error("Routing config watcher error", "error", err)
+			}
+		}
+	}()
+}
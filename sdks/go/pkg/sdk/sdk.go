@@ -1,6 +1,10 @@
 package sdk
 
-import cloudevents "github.com/cloudevents/sdk-go/v2"
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
 
 // Core is the interface that abstracts FFI and WASM implementations
 // of the EDA core functionality
@@ -46,10 +50,19 @@ const (
 // SimpleHandler processes an event without returning output events
 type SimpleHandler func(cloudevents.Event) error
 
+// ContextHandler is a SimpleHandler that also receives the message's span
+// context, for handlers that want to create their own child spans or attach
+// their own attributes/baggage.
+type ContextHandler func(context.Context, cloudevents.Event) error
+
 // OutputHandler processes an event and returns a single output event
 type OutputHandler func(cloudevents.Event) (*cloudevents.Event, error)
 
+// BatchOutputHandler processes an event and returns zero or more output
+// events, each of which is routed independently, enabling fan-out.
+type BatchOutputHandler func(cloudevents.Event) ([]*cloudevents.Event, error)
+
 // Handler is a constraint for valid handler function types
 type Handler interface {
-	SimpleHandler | OutputHandler
+	SimpleHandler | ContextHandler | OutputHandler | BatchOutputHandler
 }
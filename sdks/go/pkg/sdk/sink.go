@@ -0,0 +1,24 @@
+package sdk
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// Sink publishes an output event to a destination of the type it is
+// registered for in a SinkRegistry. Implementations should hold and reuse their own connections rather than
+// dialing on every Publish call.
+type Sink interface {
+	// Publish delivers event to dest. dest.Type is always the type this Sink
+	// was registered for.
+	Publish(ctx context.Context, dest *OutputDestination, event *cloudevents.Event) error
+}
+
+// SinkRegistry maps a destination type to the Sink that serves it.
+// DestinationDiscard is always handled by the Consumer itself and is ignored
+// if present in a registry. DestinationKafka falls back to producing on the
+// Consumer's own Kafka cluster if no sink is registered for it; register one
+// (e.g. pkg/sink/kafka) to route output events to a different cluster than
+// the one the Consumer reads from.
+type SinkRegistry map[DestinationType]Sink
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+)
+
+// defaultHTTPSinkTimeout bounds each single POST attempt; every retry gets
+// its own timeout.
+const defaultHTTPSinkTimeout = 10 * time.Second
+
+// defaultHTTPSinkMaxRetries is the number of additional attempts made after
+// a failed POST before giving up.
+const defaultHTTPSinkMaxRetries = 2
+
+// HTTPSink publishes CloudEvents over HTTP in binary content mode, POSTing
+// to dest.Target. It reuses a single http.Client (and its connection pool)
+// across every Publish call.
+type HTTPSink struct {
+	client     *http.Client
+	timeout    time.Duration
+	maxRetries int
+}
+
+// HTTPSinkOption configures an HTTPSink.
+type HTTPSinkOption func(*HTTPSink)
+
+// WithHTTPSinkTimeout sets the per-attempt request timeout.
+func WithHTTPSinkTimeout(timeout time.Duration) HTTPSinkOption {
+	return func(s *HTTPSink) {
+		s.timeout = timeout
+	}
+}
+
+// WithHTTPSinkTLSConfig sets the TLS configuration used for https:// targets.
+func WithHTTPSinkTLSConfig(tlsConfig *tls.Config) HTTPSinkOption {
+	return func(s *HTTPSink) {
+		transport := &http.Transport{TLSClientConfig: tlsConfig}
+		s.client.Transport = transport
+	}
+}
+
+// WithHTTPSinkMaxRetries sets how many additional attempts are made after an
+// initial failed publish.
+func WithHTTPSinkMaxRetries(maxRetries int) HTTPSinkOption {
+	return func(s *HTTPSink) {
+		s.maxRetries = maxRetries
+	}
+}
+
+// NewHTTPSink creates an HTTPSink with the given options applied over sane defaults.
+func NewHTTPSink(opts ...HTTPSinkOption) *HTTPSink {
+	s := &HTTPSink{
+		client:     &http.Client{},
+		timeout:    defaultHTTPSinkTimeout,
+		maxRetries: defaultHTTPSinkMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Publish POSTs event to dest.Target, retrying on any transport error or
+// non-2xx status.
+func (s *HTTPSink) Publish(ctx context.Context, dest *OutputDestination, event *cloudevents.Event) error {
+	var lastErr error
+	backoff := 200 * time.Millisecond
+
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		req, err := cehttp.NewHTTPRequestFromEvent(reqCtx, dest.Target, *event)
+		if err != nil {
+			cancel()
+			return fmt.Errorf("failed to build HTTP request: %w", err)
+		}
+
+		resp, err := s.client.Do(req)
+		cancel()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to POST event to %s: %w", dest.Target, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("unexpected HTTP status %d from %s", resp.StatusCode, dest.Target)
+	}
+
+	return lastErr
+}
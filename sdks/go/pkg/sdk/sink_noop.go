@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// NoopSink discards every event it's given, recording each call so tests can
+// assert on what would have been published without standing up a real
+// destination.
+type NoopSink struct {
+	mu        sync.Mutex
+	published []*cloudevents.Event
+}
+
+// NewNoopSink creates a NoopSink.
+func NewNoopSink() *NoopSink {
+	return &NoopSink{}
+}
+
+// Publish records event and returns nil.
+func (s *NoopSink) Publish(_ context.Context, _ *OutputDestination, event *cloudevents.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.published = append(s.published, event)
+	return nil
+}
+
+// Published returns the events recorded by Publish so far, in call order.
+func (s *NoopSink) Published() []*cloudevents.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*cloudevents.Event, len(s.published))
+	copy(out, s.published)
+	return out
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/extensions"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+)
+
+// instrumentationName identifies this package as the source of its traces
+// and metrics.
+const instrumentationName = "github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk"
+
+// telemetry bundles the tracer, meter, and metric instruments used to
+// observe message handling. It's built once in NewConsumer from the
+// configured (or default global) providers and shared across every message.
+type telemetry struct {
+	tracer trace.Tracer
+
+	handlerDuration metric.Float64Histogram
+	handlerErrors   metric.Int64Counter
+	retries         metric.Int64Counter
+	dlqCount        metric.Int64Counter
+	consumerLag     metric.Int64Gauge
+}
+
+// newTelemetry builds a telemetry bundle. A nil tp/mp falls back to the
+// global otel.GetTracerProvider()/otel.GetMeterProvider(), which are no-ops
+// until the process registers real ones.
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) (*telemetry, error) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(instrumentationName)
+
+	handlerDuration, err := meter.Float64Histogram("handler.duration",
+		metric.WithDescription("Time spent in the user handler"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create handler.duration instrument: %w", err)
+	}
+
+	handlerErrors, err := meter.Int64Counter("handler.errors",
+		metric.WithDescription("Number of handler invocations that returned an error"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create handler.errors instrument: %w", err)
+	}
+
+	retries, err := meter.Int64Counter("retries",
+		metric.WithDescription("Number of handler retries"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retries instrument: %w", err)
+	}
+
+	dlqCount, err := meter.Int64Counter("dlq.count",
+		metric.WithDescription("Number of messages published to the dead-letter topic"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dlq.count instrument: %w", err)
+	}
+
+	consumerLag, err := meter.Int64Gauge("consumer.lag",
+		metric.WithDescription("Offset lag observed at the time a message was polled"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer.lag instrument: %w", err)
+	}
+
+	return &telemetry{
+		tracer:          tp.Tracer(instrumentationName),
+		handlerDuration: handlerDuration,
+		handlerErrors:   handlerErrors,
+		retries:         retries,
+		dlqCount:        dlqCount,
+		consumerLag:     consumerLag,
+	}, nil
+}
+
+// startSpan starts a span for handling msg/event. If event carries the
+// CloudEvents distributed-tracing extension, its W3C traceparent/tracestate
+// are extracted first, so the span joins the producer's trace instead of
+// starting a new one.
+func (t *telemetry) startSpan(ctx context.Context, msg *driver.Message, event *cloudevents.Event) (context.Context, trace.Span) {
+	if dt, ok := extensions.GetDistributedTracingExtension(*event); ok {
+		ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{
+			"traceparent": dt.TraceParent,
+			"tracestate":  dt.TraceState,
+		})
+	}
+
+	return t.tracer.Start(ctx, "kafka.consume",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int64("messaging.kafka.partition", int64(msg.Partition)),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+			attribute.String("cloudevents.event_type", event.Type()),
+		))
+}
+
+// recordLag records the offset lag for msg, if the driver backing the
+// consumer is able to report one.
+func (t *telemetry) recordLag(ctx context.Context, d driver.ConsumerDriver, msg *driver.Message) {
+	reporter, ok := d.(driver.LagReporter)
+	if !ok {
+		return
+	}
+
+	lag, err := reporter.Lag(msg)
+	if err != nil {
+		return
+	}
+
+	t.consumerLag.Record(ctx, lag,
+		metric.WithAttributes(
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int64("messaging.kafka.partition", int64(msg.Partition)),
+		))
+}
@@ -5,4 +5,56 @@ type KafkaConfig struct {
 	Broker string
 	Topic  string
 	Group  string
+
+	// Auth holds SASL authentication settings. SecurityProtocol is empty for
+	// a plaintext, unauthenticated connection.
+	Auth KafkaAuthConfig
+
+	// TLS holds transport security settings, applicable whenever
+	// Auth.SecurityProtocol is "SSL" or "SASL_SSL".
+	TLS KafkaTLSConfig
+
+	// Tuning holds consumer tunables beyond the defaults the SDK otherwise applies.
+	Tuning KafkaTuningConfig
+}
+
+// KafkaAuthConfig configures SASL authentication against the broker.
+type KafkaAuthConfig struct {
+	// SecurityProtocol is one of "PLAINTEXT" (default), "SSL", "SASL_PLAINTEXT",
+	// or "SASL_SSL".
+	SecurityProtocol string
+
+	// Mechanism is one of "PLAIN", "SCRAM-SHA-256", or "SCRAM-SHA-512".
+	// Only meaningful when SecurityProtocol starts with "SASL_".
+	Mechanism string
+
+	Username string
+	Password string
+}
+
+// KafkaTLSConfig configures the TLS transport used for "SSL"/"SASL_SSL".
+type KafkaTLSConfig struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify disables broker certificate verification. Only ever
+	// meant for local development against self-signed brokers.
+	InsecureSkipVerify bool
+}
+
+// KafkaTuningConfig holds consumer tunables that operators may need to adjust
+// for secured or otherwise non-default clusters.
+type KafkaTuningConfig struct {
+	// SessionTimeoutMs is librdkafka's "session.timeout.ms". Zero uses the
+	// client default.
+	SessionTimeoutMs int
+
+	// MaxPollIntervalMs is librdkafka's "max.poll.interval.ms". Zero uses the
+	// client default.
+	MaxPollIntervalMs int
+
+	// IsolationLevel is librdkafka's "isolation.level", e.g. "read_committed".
+	// Empty uses the client default ("read_uncommitted").
+	IsolationLevel string
 }
@@ -0,0 +1,122 @@
+// Package kafka implements sdk.Sink for sdk.DestinationKafka, producing
+// routed CloudEvents to a cluster resolved by name at dispatch time. This is
+// what lets routing config send output events to a different Kafka cluster
+// than the one the Consumer itself reads from, the way MinIO resolves a
+// destination ARN to per-target config when dispatching bucket
+// notifications.
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk"
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver"
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk/driver/confluent"
+)
+
+// ClusterResolver maps a cluster name (sdk.OutputDestination.Cluster) to the
+// broker/auth config needed to connect to it.
+type ClusterResolver func(cluster string) (driver.Config, error)
+
+// Sink implements sdk.Sink for sdk.DestinationKafka. It lazily creates one
+// producer per resolved cluster and reuses it across Publish calls.
+type Sink struct {
+	resolve ClusterResolver
+
+	mu        sync.Mutex
+	producers map[string]*kafka.Producer
+}
+
+// New creates a Kafka sink that resolves destination clusters via resolve.
+func New(resolve ClusterResolver) *Sink {
+	return &Sink{
+		resolve:   resolve,
+		producers: make(map[string]*kafka.Producer),
+	}
+}
+
+// Publish produces event as a structured CloudEvent to dest.Target, on the
+// cluster named by dest.Cluster.
+func (s *Sink) Publish(ctx context.Context, dest *sdk.OutputDestination, event *cloudevents.Event) error {
+	if dest.Cluster == nil {
+		return fmt.Errorf("kafka sink: destination %q has no cluster set", dest.Target)
+	}
+
+	producer, err := s.producerFor(*dest.Cluster)
+	if err != nil {
+		return fmt.Errorf("kafka sink: failed to resolve cluster %q: %w", *dest.Cluster, err)
+	}
+
+	eventJSON, err := event.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("kafka sink: failed to serialize event: %w", err)
+	}
+
+	topic := dest.Target
+	deliveryChan := make(chan kafka.Event, 1)
+	if err := producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            []byte(event.ID()),
+		Value:          eventJSON,
+	}, deliveryChan); err != nil {
+		return fmt.Errorf("kafka sink: failed to produce to %s: %w", topic, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		msg, ok := e.(*kafka.Message)
+		if !ok {
+			return fmt.Errorf("kafka sink: unexpected delivery event %T", e)
+		}
+		if msg.TopicPartition.Error != nil {
+			return fmt.Errorf("kafka sink: delivery to %s failed: %w", topic, msg.TopicPartition.Error)
+		}
+	}
+
+	return nil
+}
+
+// producerFor returns the producer for cluster, resolving and creating one
+// on first use.
+func (s *Sink) producerFor(cluster string) (*kafka.Producer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if producer, ok := s.producers[cluster]; ok {
+		return producer, nil
+	}
+
+	cfg, err := s.resolve(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := confluent.ClientConfig(cfg)
+	producer, err := kafka.NewProducer(&cm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer for cluster %q: %w", cluster, err)
+	}
+
+	s.producers[cluster] = producer
+	return producer, nil
+}
+
+// Close flushes and closes every producer this sink has created.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for cluster, producer := range s.producers {
+		producer.Flush(5000)
+		producer.Close()
+		delete(s.producers, cluster)
+	}
+	return nil
+}
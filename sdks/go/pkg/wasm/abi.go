@@ -0,0 +1,105 @@
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/bytecodealliance/wasmtime-go/v40"
+)
+
+// abi implements the parts of the Component Model's canonical ABI that don't
+// depend on a specific WIT-defined record/variant shape: allocating guest
+// linear memory through the component's cabi_realloc export, and lowering
+// strings and byte lists to/from the resulting (ptr, len) pairs. Record and
+// variant results are shape-specific and are not decoded anywhere: the
+// export calls in wasm.go that return them report
+// errRecordDecodingUnsupported instead.
+type abi struct {
+	store   *wasmtime.Store
+	memory  *wasmtime.Memory
+	realloc *wasmtime.Func
+}
+
+// newABI resolves the linear memory and cabi_realloc export every Component
+// Model ABI call needs, regardless of the specific function being called.
+func newABI(store *wasmtime.Store, instance *wasmtime.Instance) (*abi, error) {
+	memExport := instance.GetExport(store, "memory")
+	if memExport == nil {
+		return nil, fmt.Errorf("component does not export linear memory")
+	}
+	memory := memExport.Memory()
+	if memory == nil {
+		return nil, fmt.Errorf("\"memory\" export is not a memory")
+	}
+
+	realloc := instance.GetFunc(store, "cabi_realloc")
+	if realloc == nil {
+		return nil, fmt.Errorf("component does not export cabi_realloc")
+	}
+
+	return &abi{store: store, memory: memory, realloc: realloc}, nil
+}
+
+// alloc asks the guest to allocate size bytes of linear memory at align and
+// returns the resulting pointer, via the canonical ABI's cabi_realloc(old_ptr,
+// old_size, align, new_size) convention for a fresh allocation (old_ptr=0,
+// old_size=0).
+func (a *abi) alloc(size, align int32) (int32, error) {
+	result, err := a.realloc.Call(a.store, int32(0), int32(0), align, size)
+	if err != nil {
+		return 0, fmt.Errorf("cabi_realloc failed: %w", err)
+	}
+	ptr, ok := result.(int32)
+	if !ok {
+		return 0, fmt.Errorf("cabi_realloc returned %T, want int32", result)
+	}
+	return ptr, nil
+}
+
+// lowerBytes writes data into freshly-allocated guest memory and returns the
+// (ptr, len) pair the canonical ABI uses to pass a string or list<u8>
+// argument.
+func (a *abi) lowerBytes(data []byte) (ptr, length int32, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil
+	}
+
+	ptr, err = a.alloc(int32(len(data)), 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	mem := a.memory.UnsafeData(a.store)
+	if int(ptr)+len(data) > len(mem) {
+		return 0, 0, fmt.Errorf("guest allocation out of bounds: ptr=%d len=%d memory=%d", ptr, len(data), len(mem))
+	}
+	copy(mem[ptr:], data)
+
+	return ptr, int32(len(data)), nil
+}
+
+// liftBytes reads a (ptr, len) pair back out of guest linear memory. The
+// returned slice is a copy, since the guest may reuse or free that memory.
+func (a *abi) liftBytes(ptr, length int32) ([]byte, error) {
+	if length == 0 {
+		return nil, nil
+	}
+
+	mem := a.memory.UnsafeData(a.store)
+	if int(ptr)+int(length) > len(mem) {
+		return nil, fmt.Errorf("read out of bounds: ptr=%d len=%d memory=%d", ptr, length, len(mem))
+	}
+
+	out := make([]byte, length)
+	copy(out, mem[ptr:int(ptr)+int(length)])
+	return out, nil
+}
+
+// liftString is liftBytes plus the string conversion; WIT strings are valid
+// UTF-8 by construction, per the canonical ABI.
+func (a *abi) liftString(ptr, length int32) (string, error) {
+	b, err := a.liftBytes(ptr, length)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -2,22 +2,45 @@ package wasm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/bytecodealliance/wasmtime-go/v40"
 	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk"
 	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/wasm/gen/eda/core/types"
 )
 
+// errRecordDecodingUnsupported is returned wherever an export's result is a
+// Component Model record whose field layout can't be decoded without the
+// generated accessors under pkg/wasm/gen/eda/core/types, which this tree
+// doesn't have checked in (see the Core doc comment). The export is still
+// invoked first, so a linked component's real side effects (and the real
+// classify-error category in ShouldRetry) aren't skipped — but the caller
+// gets an explicit error instead of fabricated config/routing data.
+// pkg/wasmzero hits the identical limitation and returns the analogous
+// errComponentModelUnsupported rather than guessing; this mirrors that.
+var errRecordDecodingUnsupported = errors.New("wasm: Component Model record results can't be decoded without generated bindings")
+
 // Core implements the sdk.Core interface using WASM with wasmtime-go
 //
 // This implementation calls exported functions from the Rust WASM component
 // using wasmtime-go's low-level API. It uses WIT-generated types for type safety.
+//
+// Arguments and results that lower to a single scalar or to a (ptr, len)
+// pair per the Component Model canonical ABI (strings, lists, enums) are
+// marshaled for real through abi. Record and variant results whose field
+// layout depends on the WIT definition can't be decoded without the
+// generated accessors in pkg/wasm/gen/eda/core/types, which this tree
+// doesn't have checked in (no .wit sources, no generated bindings); those
+// calls are still invoked, but the result is reported as
+// errRecordDecodingUnsupported rather than guessed at, noted inline.
 type Core struct {
 	engine   *wasmtime.Engine
 	store    *wasmtime.Store
 	instance *wasmtime.Instance
+	abi      *abi
 }
 
 // NewCore creates a new WASM-based Core implementation
@@ -41,10 +64,20 @@ func NewCore(ctx context.Context, wasmBytes []byte) (*Core, error) {
 		return nil, fmt.Errorf("failed to instantiate module: %w", err)
 	}
 
+	// abi is best-effort: components that don't export memory/cabi_realloc
+	// (e.g. a bare core-Wasm module rather than a component) get no
+	// argument/result marshaling, so the methods that need it return errors
+	// and LoadRoutingConfig logs a warning and loads nothing.
+	componentABI, err := newABI(store, instance)
+	if err != nil {
+		slog.Warn("component does not support the canonical ABI, exports that need it will return errors", "error", err)
+	}
+
 	return &Core{
 		engine:   engine,
 		store:    store,
 		instance: instance,
+		abi:      componentABI,
 	}, nil
 }
 
@@ -59,24 +92,16 @@ func (c *Core) GetKafkaConfig() (*sdk.KafkaConfig, error) {
 		fn = c.instance.GetFunc(c.store, "get-kafka-config")
 	}
 	if fn == nil {
-		// Return placeholder if function not found (component not fully linked)
-		return &sdk.KafkaConfig{
-			Broker: "localhost:9092",
-			Topic:  "events",
-			Group:  "poc",
-		}, nil
-	}
-
-	// TODO: Call the function and parse the result
-	// This requires understanding the Component Model ABI for the return type
-	// For now, return placeholder
-	_ = types.KafkaConfig{} // Type reference
-
-	return &sdk.KafkaConfig{
-		Broker: "localhost:9092",
-		Topic:  "events",
-		Group:  "poc",
-	}, nil
+		return nil, fmt.Errorf("component does not export get-kafka-config")
+	}
+
+	if _, err := fn.Call(c.store); err != nil {
+		return nil, fmt.Errorf("get-kafka-config call failed: %w", err)
+	}
+	_ = types.KafkaConfig{} // Type reference, pending generated accessors
+
+	// KafkaConfig is a record result: see errRecordDecodingUnsupported.
+	return nil, fmt.Errorf("get Kafka config: %w", errRecordDecodingUnsupported)
 }
 
 // ShouldRetry checks if an error should be retried
@@ -93,21 +118,37 @@ func (c *Core) ShouldRetry(errorMsg string, attempt uint32) (bool, error) {
 		retryFn = c.instance.GetFunc(c.store, "get-retry-decision")
 	}
 
-	if classifyFn == nil || retryFn == nil {
-		// Return placeholder if functions not found
-		return false, nil
+	if classifyFn == nil || retryFn == nil || c.abi == nil {
+		return false, fmt.Errorf("component does not export classify-error/get-retry-decision, or doesn't support the canonical ABI")
 	}
 
-	// TODO: Call the functions with proper Component Model ABI
-	// This requires:
-	// 1. Marshaling string to Component Model format
-	// 2. Calling classify-error
-	// 3. Calling get-retry-decision with the result
-	// 4. Unmarshaling the RetryDecision result
-	_ = types.ErrorCategory(0) // Type reference
-	_ = types.RetryDecision{}  // Type reference
+	// classify-error(string) -> enum lowers to a single (ptr, len) argument
+	// and a single i32 discriminant result, so this call is real.
+	ptr, length, err := c.abi.lowerBytes([]byte(errorMsg))
+	if err != nil {
+		return false, fmt.Errorf("failed to lower error message: %w", err)
+	}
+	result, err := classifyFn.Call(c.store, ptr, length)
+	if err != nil {
+		return false, fmt.Errorf("classify-error call failed: %w", err)
+	}
+	discriminant, ok := result.(int32)
+	if !ok {
+		return false, fmt.Errorf("classify-error returned %T, want int32", result)
+	}
+	category := types.ErrorCategory(discriminant)
+
+	// get-retry-decision returns the RetryDecision record, whose field
+	// layout depends on the WIT definition. Without the generated bindings
+	// under pkg/wasm/gen/eda/core/types to describe that layout, the call
+	// is made (so a component that does support it sees the real category
+	// and isn't skipped), but the result can't be decoded yet: see
+	// errRecordDecodingUnsupported.
+	if _, err := retryFn.Call(c.store, int32(attempt), int32(category)); err != nil {
+		return false, fmt.Errorf("get-retry-decision call failed: %w", err)
+	}
 
-	return false, nil
+	return false, fmt.Errorf("get retry decision: %w", errRecordDecodingUnsupported)
 }
 
 // CalculateBackoff calculates backoff duration in milliseconds
@@ -120,12 +161,17 @@ func (c *Core) CalculateBackoff(attempt uint32) (uint64, error) {
 	}
 
 	if fn == nil {
-		// Return placeholder if function not found
-		return 0, nil
+		return 0, fmt.Errorf("component does not export get-retry-decision")
 	}
 
-	// TODO: Call the function with proper Component Model ABI
-	return 0, nil
+	// Unlike ShouldRetry, CalculateBackoff isn't given the error, so there's
+	// no error-category argument to call get-retry-decision with here; it's
+	// the same export, already invoked (with the real category) from
+	// ShouldRetry just before this is called. Even if it were called again,
+	// get-retry-decision returns a RetryDecision record whose layout isn't
+	// known without the generated bindings, so the backoff field couldn't be
+	// read out yet: see errRecordDecodingUnsupported.
+	return 0, fmt.Errorf("calculate backoff: %w", errRecordDecodingUnsupported)
 }
 
 // GetOutputDestination routes an output event to its destination
@@ -137,38 +183,67 @@ func (c *Core) GetOutputDestination(eventJSON string) (*sdk.OutputDestination, e
 		fn = c.instance.GetFunc(c.store, "get-output-destination")
 	}
 
-	if fn == nil {
-		// Return default destination if function not found
-		cluster := "default"
-		return &sdk.OutputDestination{
-			Type:    sdk.DestinationKafka,
-			Target:  "events",
-			Cluster: &cluster,
-		}, nil
-	}
-
-	// TODO: Call the function with proper Component Model ABI
-	// This requires:
-	// 1. Marshaling eventJSON string to Component Model format
-	// 2. Calling get-output-destination
-	// 3. Unmarshaling the OutputDestination result
-	_ = types.OutputDestination{} // Type reference
-
-	// Return default for now
-	cluster := "default"
-	return &sdk.OutputDestination{
-		Type:    sdk.DestinationKafka,
-		Target:  "events",
-		Cluster: &cluster,
-	}, nil
+	if fn == nil || c.abi == nil {
+		return nil, fmt.Errorf("component does not export get-output-destination, or doesn't support the canonical ABI")
+	}
+
+	// eventJSON lowers to a real (ptr, len) argument. The OutputDestination
+	// result is a record, so - same blocker as ShouldRetry - it's still
+	// invoked but decoding the response needs the generated field accessors
+	// under pkg/wasm/gen/eda/core/types.
+	ptr, length, err := c.abi.lowerBytes([]byte(eventJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lower event JSON: %w", err)
+	}
+	if _, err := fn.Call(c.store, ptr, length); err != nil {
+		return nil, fmt.Errorf("get-output-destination call failed: %w", err)
+	}
+	_ = types.OutputDestination{} // Type reference, pending generated accessors
+
+	return nil, fmt.Errorf("get output destination: %w", errRecordDecodingUnsupported)
 }
 
-// LoadRoutingConfig loads routing configuration from a YAML file
-// Note: WASM implementation doesn't support file I/O
+// LoadRoutingConfig loads routing configuration from a YAML file.
+//
+// The WASM guest has no file system access, so the host (this method) reads
+// filePath itself and passes the raw bytes to the guest's
+// load-routing-config-bytes export as a list<u8>, rather than a path the
+// guest couldn't open. That export is expected to return a single i32 status
+// (0 for success, non-zero for a parse/validation failure), which is a plain
+// scalar result and so, unlike the record-returning exports above, can be
+// decoded without the generated bindings.
 func (c *Core) LoadRoutingConfig(filePath string) error {
-	// WASM components don't have direct file system access
-	// Routing configuration would need to be passed differently (e.g., via imports)
-	slog.Warn("WASM core does not support loading routing config from files", "file", filePath)
+	fn := c.instance.GetFunc(c.store, "eda:core/config@0.1.0#load-routing-config-bytes")
+	if fn == nil {
+		fn = c.instance.GetFunc(c.store, "load-routing-config-bytes")
+	}
+	if fn == nil || c.abi == nil {
+		slog.Warn("component does not export load-routing-config-bytes, routing config not loaded", "file", filePath)
+		return nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read routing config %s: %w", filePath, err)
+	}
+
+	ptr, length, err := c.abi.lowerBytes(data)
+	if err != nil {
+		return fmt.Errorf("failed to lower routing config bytes: %w", err)
+	}
+
+	result, err := fn.Call(c.store, ptr, length)
+	if err != nil {
+		return fmt.Errorf("load-routing-config-bytes call failed: %w", err)
+	}
+	status, ok := result.(int32)
+	if !ok {
+		return fmt.Errorf("load-routing-config-bytes returned %T, want int32", result)
+	}
+	if status != 0 {
+		return fmt.Errorf("load-routing-config-bytes rejected routing config %s: status %d", filePath, status)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,106 @@
+// Package wasmzero implements sdk.Core using tetratelabs/wazero, a pure-Go
+// WebAssembly runtime. It mirrors pkg/wasm, which uses wasmtime-go instead:
+// wasmtime-go requires CGO and a native runtime, which complicates static
+// binaries, cross-compilation, and scratch-container images. Use this
+// package instead when a fully static, CGO-free build matters more than
+// wasmtime's performance.
+package wasmzero
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/openshift-knative/func-eda-mlang/sdks/go/pkg/sdk"
+)
+
+// errComponentModelUnsupported is returned by every Core method that would
+// need to call into the Rust WASM component's Component Model (wasip2)
+// exports. wazero implements the WASM core spec but not the Component Model
+// ABI used by those exports (string/record marshaling, canonical lifting and
+// lowering), so there is no correct way to call eda:core/config,
+// eda:core/retry, or eda:core/routing today. Earlier revisions of this file
+// returned hardcoded placeholder values instead, which silently produced
+// wrong routing/retry decisions rather than failing; an explicit error is
+// the honest behavior until wazero (or a generated host-binding shim) closes
+// that gap.
+var errComponentModelUnsupported = errors.New("wasmzero: Component Model exports are not callable from wazero yet")
+
+// Core implements the sdk.Core interface using WASM with wazero
+//
+// It compiles and instantiates the Rust WASM component with wazero, but none
+// of its methods (other than Close) call into the component: each returns
+// errComponentModelUnsupported without looking up or invoking any export.
+type Core struct {
+	ctx     context.Context
+	runtime wazero.Runtime
+	module  api.Module
+}
+
+// NewCore creates a new wazero-based Core implementation.
+// wasmBytes is the WASM component/module bytes.
+func NewCore(ctx context.Context, wasmBytes []byte) (*Core, error) {
+	runtime := wazero.NewRuntime(ctx)
+
+	compiled, err := runtime.CompileModule(ctx, wasmBytes)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to compile module: %w", err)
+	}
+
+	module, err := runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig())
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to instantiate module: %w", err)
+	}
+
+	return &Core{
+		ctx:     ctx,
+		runtime: runtime,
+		module:  module,
+	}, nil
+}
+
+// GetKafkaConfig always returns errComponentModelUnsupported; the
+// "eda:core/config@0.1.0#get-kafka-config" export is not called.
+func (c *Core) GetKafkaConfig() (*sdk.KafkaConfig, error) {
+	return nil, fmt.Errorf("get Kafka config: %w", errComponentModelUnsupported)
+}
+
+// ShouldRetry always returns errComponentModelUnsupported; the
+// classify-error and get-retry-decision exports are not called.
+func (c *Core) ShouldRetry(errorMsg string, attempt uint32) (bool, error) {
+	return false, fmt.Errorf("classify error for retry: %w", errComponentModelUnsupported)
+}
+
+// CalculateBackoff always returns errComponentModelUnsupported; the
+// get-retry-decision export is not called.
+func (c *Core) CalculateBackoff(attempt uint32) (uint64, error) {
+	return 0, fmt.Errorf("calculate backoff: %w", errComponentModelUnsupported)
+}
+
+// GetOutputDestination always returns errComponentModelUnsupported; the
+// get-output-destination export is not called.
+func (c *Core) GetOutputDestination(eventJSON string) (*sdk.OutputDestination, error) {
+	return nil, fmt.Errorf("get output destination: %w", errComponentModelUnsupported)
+}
+
+// LoadRoutingConfig loads routing configuration from a YAML file.
+//
+// WASM components have no direct filesystem access, so even once
+// errComponentModelUnsupported is resolved, this will need routing config
+// passed in some other way (e.g. a host import) rather than a file path.
+func (c *Core) LoadRoutingConfig(filePath string) error {
+	return fmt.Errorf("load routing config %q: %w", filePath, errComponentModelUnsupported)
+}
+
+// Close releases resources held by the wazero runtime
+func (c *Core) Close() error {
+	if err := c.runtime.Close(c.ctx); err != nil {
+		return fmt.Errorf("failed to close wazero runtime: %w", err)
+	}
+	return nil
+}